@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/dustinxie/ecc"
 	"golang.org/x/crypto/argon2"
@@ -123,6 +124,18 @@ func (a Account) SignTx(tx Tx) (SigTx, error) {
   return stx, nil
 }
 
+// ApproveTx approves the validator tx by signing the tx hash
+func (a Account) ApproveTx(tx SigTx) (SigTx, error) {
+  hash := tx.Tx.Hash().Bytes()
+  sig, err := ecc.SignBytes(a.prv, hash, ecc.LowerS | ecc.RecID)
+  if err != nil {
+    return SigTx{}, err
+  }
+  appr := Approval{Validator: a.Address(), Sig: sig}
+  tx.Approvals = append(slices.Clone(tx.Approvals), appr)
+  return tx, nil
+}
+
 func (a Account) SignBlock(blk Block) (SigBlock, error) {
   hash := blk.Hash().Bytes()
   sig, err := ecc.SignBytes(a.prv, hash, ecc.LowerS | ecc.RecID)
@@ -133,6 +146,26 @@ func (a Account) SignBlock(blk Block) (SigBlock, error) {
   return sblk, nil
 }
 
+func (a Account) SignVote(vote Vote) (SigVote, error) {
+  hash := vote.Hash().Bytes()
+  sig, err := ecc.SignBytes(a.prv, hash, ecc.LowerS | ecc.RecID)
+  if err != nil {
+    return SigVote{}, err
+  }
+  svote := NewSigVote(vote, sig)
+  return svote, nil
+}
+
+func (a Account) SignProposal(prop Proposal) (SigProposal, error) {
+  hash := prop.Hash().Bytes()
+  sig, err := ecc.SignBytes(a.prv, hash, ecc.LowerS | ecc.RecID)
+  if err != nil {
+    return SigProposal{}, err
+  }
+  sprop := NewSigProposal(prop, sig)
+  return sprop, nil
+}
+
 func (a Account) encodePrivateKey() ([]byte, error) {
   return json.Marshal(newP256k1PrivateKey(a.prv))
 }
@@ -0,0 +1,146 @@
+package chain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/kv"
+)
+
+const archiveFile = "archive.kv"
+
+var archiveHeightKey = []byte("height")
+
+func archiveAccPrefix(acc Address) string {
+  return fmt.Sprintf("acc/%v/", acc)
+}
+
+func archiveAccKey(acc Address, number uint64) []byte {
+  return []byte(fmt.Sprintf("%v%016x", archiveAccPrefix(acc), number))
+}
+
+func archiveBlkKey(number uint64) []byte {
+  return []byte(fmt.Sprintf("blk/%016x", number))
+}
+
+// Archive stores the balance and the nonce of every account changed by a block
+// at the block number. The account state at any block number is the latest
+// stored account state at or before the block number. The accounts changed by
+// every block are indexed by the block number for the truncation
+type Archive struct {
+  db *kv.DB
+}
+
+func OpenArchive(dir string) (*Archive, error) {
+  err := os.MkdirAll(dir, 0700)
+  if err != nil {
+    return nil, err
+  }
+  db, err := kv.Open(filepath.Join(dir, archiveFile))
+  if err != nil {
+    return nil, err
+  }
+  return &Archive{db: db}, nil
+}
+
+// Height returns the last archived block number and whether the genesis has
+// been archived
+func (a *Archive) Height() (uint64, bool, error) {
+  number, exist, err := a.db.Get(archiveHeightKey)
+  if err != nil || !exist {
+    return 0, false, err
+  }
+  return binary.BigEndian.Uint64(number), true, nil
+}
+
+func (a *Archive) write(
+  number uint64, accs []Address, balances, nonces map[Address]uint64,
+) error {
+  var batch kv.Batch
+  blkAccs := make([]string, len(accs))
+  for i, acc := range accs {
+    val := binary.BigEndian.AppendUint64(nil, balances[acc])
+    val = binary.BigEndian.AppendUint64(val, nonces[acc])
+    batch.Put(archiveAccKey(acc, number), val)
+    blkAccs[i] = string(acc)
+  }
+  batch.Put(archiveBlkKey(number), []byte(strings.Join(blkAccs, "\n")))
+  batch.Put(archiveHeightKey, binary.BigEndian.AppendUint64(nil, number))
+  return a.db.Write(&batch)
+}
+
+func (a *Archive) WriteGenesis(gen SigGenesis) error {
+  accs := make([]Address, 0, len(gen.Balances))
+  for acc := range gen.Balances {
+    accs = append(accs, acc)
+  }
+  return a.write(0, accs, gen.Balances, nil)
+}
+
+// WriteBlock archives the state of the accounts changed by the block including
+// the tx parties, the fee recipient, and the penalized validators. The state
+// must be the state right after the block application
+func (a *Archive) WriteBlock(blk SigBlock, state *State) error {
+  state.mtx.RLock()
+  defer state.mtx.RUnlock()
+  accs := slices.Sorted(maps.Keys(state.touched))
+  return a.write(blk.Number, accs, state.balances, state.nonces)
+}
+
+func (a *Archive) Account(acc Address, number uint64) (AccState, bool, error) {
+  // Find the latest account state at or before the block number
+  prefix := []byte(archiveAccPrefix(acc))
+  key, exist := a.db.Floor(prefix, archiveAccKey(acc, number))
+  if !exist {
+    return AccState{}, false, nil
+  }
+  val, exist, err := a.db.Get([]byte(key))
+  if err != nil || !exist {
+    return AccState{}, false, err
+  }
+  accState := AccState{
+    Account: acc,
+    Balance: binary.BigEndian.Uint64(val[0:8]),
+    Nonce: binary.BigEndian.Uint64(val[8:16]),
+  }
+  return accState, true, nil
+}
+
+// Truncate deletes the account states archived after the block number on the
+// chain reorg
+func (a *Archive) Truncate(number uint64) error {
+  height, exist, err := a.Height()
+  if err != nil || !exist || height <= number {
+    return err
+  }
+  var batch kv.Batch
+  blkKeys := a.db.Range(archiveBlkKey(number + 1), archiveBlkKey(height + 1))
+  for _, blkKey := range blkKeys {
+    var n uint64
+    _, err := fmt.Sscanf(blkKey, "blk/%016x", &n)
+    if err != nil {
+      return err
+    }
+    accs, _, err := a.db.Get([]byte(blkKey))
+    if err != nil {
+      return err
+    }
+    for _, acc := range strings.Split(string(accs), "\n") {
+      if len(acc) > 0 {
+        batch.Delete(archiveAccKey(Address(acc), n))
+      }
+    }
+    batch.Delete([]byte(blkKey))
+  }
+  batch.Put(archiveHeightKey, binary.BigEndian.AppendUint64(nil, number))
+  return a.db.Write(&batch)
+}
+
+func (a *Archive) Close() error {
+  return a.db.Close()
+}
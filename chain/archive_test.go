@@ -0,0 +1,182 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestArchiveWriteReadAccount(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Open the archive and archive the genesis balances
+  archive, err := chain.OpenArchive(blockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer archive.Close()
+  err = archive.WriteGenesis(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create several blocks and archive the account states after every block
+  ownerAcc, ownerBal := genesisAccount(gen)
+  state := chain.NewState(gen)
+  expBalances := []uint64{ownerBal}
+  _, err = createStoreBlocks(
+    gen, 3, func(blk chain.SigBlock) error {
+      err := state.ApplyBlockToState(blk)
+      if err != nil {
+        return err
+      }
+      balance, _ := state.Balance(ownerAcc)
+      expBalances = append(expBalances, balance)
+      return archive.WriteBlock(blk, state)
+    },
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the archive height equals the last block number
+  height, _, err := archive.Height()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if height != 3 {
+    t.Errorf("invalid archive height: expected 3, got %v", height)
+  }
+  // Verify that the archived account balance and nonce are correct at every
+  // block number including the genesis
+  for number, expBalance := range expBalances {
+    accState, exist, err := archive.Account(ownerAcc, uint64(number))
+    if err != nil {
+      t.Fatal(err)
+    }
+    if !exist {
+      t.Fatalf("account does not exist at block %v", number)
+    }
+    if accState.Balance != expBalance {
+      t.Errorf(
+        "invalid balance at block %v: expected %v, got %v",
+        number, expBalance, accState.Balance,
+      )
+    }
+    // Every block contains two transactions from the initial owner account
+    if accState.Nonce != uint64(number * 2) {
+      t.Errorf(
+        "invalid nonce at block %v: expected %v, got %v",
+        number, number * 2, accState.Nonce,
+      )
+    }
+  }
+  // Verify that the account does not exist before the first transaction
+  _, exist, err := archive.Account("to", 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if exist {
+    t.Errorf("account exists before the first transaction")
+  }
+  // Truncate the archive after the first block on the chain reorg
+  err = archive.Truncate(1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the archive height and the account state roll back to the
+  // first block
+  height, _, err = archive.Height()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if height != 1 {
+    t.Errorf("invalid archive height: expected 1, got %v", height)
+  }
+  accState, _, err := archive.Account(ownerAcc, 3)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if accState.Balance != expBalances[1] || accState.Nonce != 2 {
+    t.Errorf(
+      "invalid truncated account: expected %v, got %v",
+      expBalances[1], accState.Balance,
+    )
+  }
+}
+
+func TestArchiveFeeRecipient(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Open the archive and archive the genesis balances
+  archive, err := chain.OpenArchive(blockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer archive.Close()
+  err = archive.WriteGenesis(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create and archive the block with the tx that pays the fee to the
+  // authority
+  state := chain.NewState(gen)
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  tx.Fee = 3
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blk, err := state.Clone().CreateBlock(auth, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.ApplyBlockToState(blk)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = archive.WriteBlock(blk, state)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the fee credited to the authority is archived
+  accState, exist, err := archive.Account(auth.Address(), 1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !exist {
+    t.Fatalf("fee recipient is not archived")
+  }
+  if accState.Balance != tx.Fee {
+    t.Errorf(
+      "invalid fee recipient balance: expected %v, got %v",
+      tx.Fee, accState.Balance,
+    )
+  }
+}
@@ -2,9 +2,12 @@ package chain
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"hash/crc32"
 	"os"
+	"strconv"
 	"path/filepath"
 	"strings"
 	"time"
@@ -24,67 +27,175 @@ func InitBlockStore(dir string) error {
   return nil
 }
 
+var blockCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// encodeBlockRecord prefixes the block with the crc32 checksum of the block to
+// detect a torn or corrupted block record in the block store
+func encodeBlockRecord(jblk []byte) []byte {
+  sum := crc32.Checksum(jblk, blockCRCTable)
+  rec := make([]byte, 0, len(jblk) + 10)
+  rec = fmt.Appendf(rec, "%08x ", sum)
+  rec = append(rec, jblk...)
+  return append(rec, '\n')
+}
+
+// decodeBlockRecord verifies the block record checksum and returns the block.
+// Legacy block records without a checksum are returned as is
+func decodeBlockRecord(rec []byte) ([]byte, error) {
+  rec = bytes.TrimSuffix(rec, []byte("\n"))
+  if len(rec) > 0 && rec[0] == '{' {
+    return rec, nil
+  }
+  if len(rec) < 10 || rec[8] != ' ' {
+    return nil, fmt.Errorf("malformed block record")
+  }
+  sum, err := strconv.ParseUint(string(rec[:8]), 16, 32)
+  if err != nil {
+    return nil, fmt.Errorf("malformed block record: %v", err)
+  }
+  jblk := rec[9:]
+  if crc32.Checksum(jblk, blockCRCTable) != uint32(sum) {
+    return nil, fmt.Errorf("block record checksum mismatch")
+  }
+  return jblk, nil
+}
+
 type Block struct {
+  Version uint64 `json:"version,omitempty"`
   Number uint64 `json:"number"`
   Parent Hash `json:"parent"`
   Txs []SigTx `json:"txs"`
+  Evidence []Evidence `json:"evidence,omitempty"`
   merkleTree []Hash
   MerkleRoot Hash `json:"merkleRoot"`
+  StateRoot Hash `json:"stateRoot"`
+  Round uint64 `json:"round,omitempty"`
+  Nonce uint64 `json:"nonce,omitempty"`
+  Difficulty uint64 `json:"difficulty,omitempty"`
+  TotalWork uint64 `json:"totalWork,omitempty"`
   Time time.Time `json:"time"`
 }
 
-func NewBlock(number uint64, parent Hash, txs []SigTx) (Block, error) {
-  merkleTree, err := MerkleHash(txs, TxHash, TxPairHash)
+func NewBlock(
+  number uint64, parent Hash, txs []SigTx, stateRoot Hash,
+) (Block, error) {
+  merkleTree, err := MerkleHash(
+    txs, TxHash, MerklePairHash(EncodingVersion),
+  )
   if err != nil {
     return Block{}, err
   }
   blk := Block{
-    Number: number, Parent: parent, Txs: txs,
-    merkleTree: merkleTree, MerkleRoot: merkleTree[0],
+    Version: EncodingVersion, Number: number, Parent: parent, Txs: txs,
+    merkleTree: merkleTree, MerkleRoot: merkleTree[0], StateRoot: stateRoot,
     Time: time.Now(),
   }
   return blk, nil
 }
 
 func (b Block) Hash() Hash {
-  return NewHash(b)
+  if b.Version == LegacyEncoding {
+    return NewHash(newLegacyBlock(b))
+  }
+  return keccak(b.Encode())
+}
+
+// legacyBlock mirrors the JSON encoding of the block before the encoding
+// versions. The later fields are omitted when not set, so the legacy block
+// hash of the existing chain is preserved
+type legacyBlock struct {
+  Number uint64 `json:"number"`
+  Parent Hash `json:"parent"`
+  Txs []SigTx `json:"txs"`
+  Evidence []Evidence `json:"evidence,omitempty"`
+  MerkleRoot Hash `json:"merkleRoot"`
+  StateRoot *Hash `json:"stateRoot,omitempty"`
+  Round uint64 `json:"round,omitempty"`
+  Nonce uint64 `json:"nonce,omitempty"`
+  Difficulty uint64 `json:"difficulty,omitempty"`
+  TotalWork uint64 `json:"totalWork,omitempty"`
+  Time time.Time `json:"time"`
+}
+
+type legacySigBlock struct {
+  legacyBlock
+  Sig []byte `json:"sig"`
+}
+
+func newLegacyBlock(b Block) legacyBlock {
+  blk := legacyBlock{
+    Number: b.Number, Parent: b.Parent, Txs: b.Txs, Evidence: b.Evidence,
+    MerkleRoot: b.MerkleRoot, Round: b.Round, Nonce: b.Nonce,
+    Difficulty: b.Difficulty, TotalWork: b.TotalWork, Time: b.Time,
+  }
+  if b.StateRoot != (Hash{}) {
+    blk.StateRoot = &b.StateRoot
+  }
+  return blk
 }
 
 type SigBlock struct {
   Block
   Sig []byte `json:"sig"`
+  Commit *Commit `json:"commit,omitempty"`
 }
 
 func NewSigBlock(blk Block, sig []byte) SigBlock {
   return SigBlock{Block: blk, Sig: sig}
 }
 
+// Hash excludes the commit as the validators vote for the block hash before
+// the block is committed
 func (b SigBlock) Hash() Hash {
-  return NewHash(b)
+  if b.Version == LegacyEncoding {
+    return NewHash(legacySigBlock{newLegacyBlock(b.Block), b.Sig})
+  }
+  return keccak(b.Encode())
 }
 
 func (b SigBlock) String() string {
   var bld strings.Builder
   bld.WriteString(
     fmt.Sprintf(
-      "blk %7d: %.7s -> %.7s   mrk %.7s\n",
-      b.Number, b.Hash(), b.Parent, b.MerkleRoot,
+      "blk %7d: %.7s -> %.7s   mrk %.7s   stt %.7s\n",
+      b.Number, b.Hash(), b.Parent, b.MerkleRoot, b.StateRoot,
     ),
   )
   for _, tx := range b.Txs {
     bld.WriteString(fmt.Sprintf("%v\n", tx))
   }
+  for _, ev := range b.Evidence {
+    bld.WriteString(fmt.Sprintf("%v\n", ev))
+  }
   return bld.String()
 }
 
 func (b SigBlock) Write(dir string) error {
+  jblk, err := json.Marshal(b)
+  if err != nil {
+    return err
+  }
+  rec := encodeBlockRecord(jblk)
   path := filepath.Join(dir, blocksFile)
   file, err := os.OpenFile(path, os.O_CREATE | os.O_APPEND | os.O_WRONLY, 0600)
   if err != nil {
     return err
   }
   defer file.Close()
-  return json.NewEncoder(file).Encode(b)
+  info, err := file.Stat()
+  if err != nil {
+    return err
+  }
+  _, err = file.Write(rec)
+  if err != nil {
+    return err
+  }
+  // Sync the block to the stable storage before indexing the block
+  err = file.Sync()
+  if err != nil {
+    return err
+  }
+  return writeBlockIndex(dir, b, info.Size(), int64(len(rec)))
 }
 
 func ReadBlocks(dir string) (
@@ -107,8 +218,13 @@ func ReadBlocks(dir string) (
         yield(err, SigBlock{})
         return
       }
+      jblk, err := decodeBlockRecord(sca.Bytes())
+      if err != nil {
+        more = yield(err, SigBlock{})
+        continue
+      }
       var blk SigBlock
-      err = json.Unmarshal(sca.Bytes(), &blk)
+      err = json.Unmarshal(jblk, &blk)
       if err != nil {
         more = yield(err, SigBlock{})
         continue
@@ -139,18 +255,25 @@ func ReadBlocksBytes(dir string) (
         yield(err, nil)
         return
       }
-      more = yield(nil, sca.Bytes())
+      jblk, err := decodeBlockRecord(sca.Bytes())
+      if err != nil {
+        yield(err, nil)
+        return
+      }
+      more = yield(nil, jblk)
     }
   }
   return blocks, close, nil
 }
 
-func VerifyBlock(blk SigBlock, authority Address) (bool, error) {
+// VerifyBlock verifies that the block is signed by the validator whose turn it
+// is to propose the block at the block number in the block round
+func VerifyBlock(blk SigBlock, validators []Address) (bool, error) {
   hash := blk.Block.Hash().Bytes()
   pub, err := ecc.RecoverPubkey("P-256k1", hash, blk.Sig)
   if err != nil {
     return false, err
   }
   acc := NewAddress(pub)
-  return acc == authority, nil
+  return acc == Proposer(validators, blk.Number, blk.Round), nil
 }
@@ -30,7 +30,9 @@ func TestBlockSignBlockWriteReadVerifyBlock(t *testing.T) {
     t.Fatal(err)
   }
   // Create and sign a transaction with the initial owner account
-  tx := chain.NewTx(chain.Address("from"), chain.Address("to"), 12, 1)
+  tx := chain.NewTx(
+    chainName, chain.Address("from"), chain.Address("to"), 12, 1,
+  )
   stx, err := acc.SignTx(tx)
   if err != nil {
     t.Fatal(err)
@@ -38,7 +40,7 @@ func TestBlockSignBlockWriteReadVerifyBlock(t *testing.T) {
   // Create and sign a block with the authority account
   txs := make([]chain.SigTx, 0, 1)
   txs = append(txs, stx)
-  blk, err := chain.NewBlock(1, gen.Hash(), txs)
+  blk, err := chain.NewBlock(1, gen.Hash(), txs, chain.Hash{})
   if err != nil {
     t.Fatal(err)
   }
@@ -62,7 +64,7 @@ func TestBlockSignBlockWriteReadVerifyBlock(t *testing.T) {
       t.Fatal(err)
     }
     // Verify that the signature of the signed block is valid
-    valid, err := chain.VerifyBlock(blk, auth.Address())
+    valid, err := chain.VerifyBlock(blk, []chain.Address{auth.Address()})
     if err != nil {
       t.Fatal(err)
     }
@@ -0,0 +1,65 @@
+package chain
+
+import "fmt"
+
+const (
+  FileStoreType = "file"
+  KVStoreType = "kv"
+)
+
+type TxPos struct {
+  Number uint64 `json:"number"`
+  Index uint64 `json:"index"`
+}
+
+func (p TxPos) Less(q TxPos) bool {
+  return p.Number < q.Number || p.Number == q.Number && p.Index < q.Index
+}
+
+// Recovery reports the block store height and the number of bytes of a torn or
+// corrupted tail truncated from the block store on open
+type Recovery struct {
+  Height uint64
+  DroppedBytes int64
+}
+
+func (r Recovery) String() string {
+  return fmt.Sprintf(
+    "height %v, dropped %v bytes of a torn tail", r.Height, r.DroppedBytes,
+  )
+}
+
+type BlockStore interface {
+  WriteBlock(blk SigBlock) error
+  Height() (uint64, error)
+  Block(number uint64) (SigBlock, error)
+  BlockBytes(number uint64) ([]byte, error)
+  BlocksBytes(from uint64) (
+    func(yield func(err error, jblk []byte) bool), func(), error,
+  )
+  // FindBlock looks up the block number by the full block hash in O(1) or by
+  // the block hash prefix by scanning the hash index
+  FindBlock(hashPrefix string) (uint64, bool, error)
+  // FindTx looks up the block number and the tx position in the block by the
+  // full tx hash in O(1) or by the tx hash prefix by scanning the tx index
+  FindTx(hashPrefix string) (Hash, TxPos, bool, error)
+  // AccountTxs returns the positions of the txs sent or received by the
+  // account ordered by the block number and the tx position in the block
+  AccountTxs(acc Address) ([]TxPos, error)
+  // Truncate removes the blocks after the block number together with the
+  // index records of the removed blocks on the chain reorg
+  Truncate(number uint64) error
+  Recovery() Recovery
+  Close() error
+}
+
+func OpenBlockStore(dir, storeType string) (BlockStore, error) {
+  switch storeType {
+  case FileStoreType, "":
+    return OpenFileStore(dir)
+  case KVStoreType:
+    return OpenKVStore(dir)
+  default:
+    return nil, fmt.Errorf("unsupported block store type: %v", storeType)
+  }
+}
@@ -0,0 +1,203 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func createStoreBlocks(
+  gen chain.SigGenesis, count int, writeBlock func(blk chain.SigBlock) error,
+) ([]chain.SigBlock, error) {
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    return nil, err
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    return nil, err
+  }
+  state := chain.NewState(gen)
+  blks := make([]chain.SigBlock, 0, count)
+  for i := range count {
+    for _, value := range []uint64{uint64(i + 1), uint64(i + 2)} {
+      tx := chain.NewTx(
+        chainName, acc.Address(), chain.Address("to"), value,
+        state.Pending.Nonce(acc.Address()) + 1,
+      )
+      stx, err := acc.SignTx(tx)
+      if err != nil {
+        return nil, err
+      }
+      err = state.Pending.ApplyTx(stx)
+      if err != nil {
+        return nil, err
+      }
+    }
+    clone := state.Clone()
+    blk, err := clone.CreateBlock(auth, 0)
+    if err != nil {
+      return nil, err
+    }
+    clone = state.Clone()
+    err = clone.ApplyBlock(blk)
+    if err != nil {
+      return nil, err
+    }
+    state.Apply(clone)
+    err = writeBlock(blk)
+    if err != nil {
+      return nil, err
+    }
+    blks = append(blks, blk)
+  }
+  return blks, nil
+}
+
+func verifyBlockStore(
+  t *testing.T, blockStore chain.BlockStore, blks []chain.SigBlock,
+) {
+  // Verify that the block store height equals the number of written blocks
+  height, err := blockStore.Height()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if height != uint64(len(blks)) {
+    t.Errorf("invalid height: expected %v, got %v", len(blks), height)
+  }
+  for _, exp := range blks {
+    // Verify that the block is found by the block number
+    got, err := blockStore.Block(exp.Number)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if got.Hash() != exp.Hash() {
+      t.Errorf("invalid block %v by number", exp.Number)
+    }
+    // Verify that the block number is found by the full block hash and by the
+    // block hash prefix
+    hash := exp.Hash().String()
+    for _, hash := range []string{hash, hash[:7]} {
+      number, found, err := blockStore.FindBlock(hash)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if !found || number != exp.Number {
+        t.Errorf("invalid block %v by hash %v", exp.Number, hash)
+      }
+    }
+    // Verify that the block number and the tx position are found by the tx
+    // hash
+    for i, tx := range exp.Txs {
+      _, pos, found, err := blockStore.FindTx(tx.Hash().String())
+      if err != nil {
+        t.Fatal(err)
+      }
+      if !found || pos.Number != exp.Number || pos.Index != uint64(i) {
+        t.Errorf("invalid tx position %v: %v", tx.Hash(), pos)
+      }
+    }
+  }
+  // Verify that the tx positions of the recipient account are ordered by the
+  // block number and the tx position in the block
+  expPoss := make([]chain.TxPos, 0)
+  for _, blk := range blks {
+    for i := range blk.Txs {
+      pos := chain.TxPos{Number: blk.Number, Index: uint64(i)}
+      expPoss = append(expPoss, pos)
+    }
+  }
+  gotPoss, err := blockStore.AccountTxs(chain.Address("to"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !slices.Equal(gotPoss, expPoss) {
+    t.Errorf("invalid account txs: expected %v, got %v", expPoss, gotPoss)
+  }
+  // Verify that an unknown block is not found
+  _, found, err := blockStore.FindBlock(chain.NewHash("unknown").String())
+  if err != nil {
+    t.Fatal(err)
+  }
+  if found {
+    t.Errorf("unknown block is found")
+  }
+  _, err = blockStore.Block(uint64(len(blks) + 1))
+  if err == nil {
+    t.Errorf("expected block not found error, got none")
+  }
+}
+
+func TestBlockStore(t *testing.T) {
+  for _, storeType := range []string{chain.FileStoreType, chain.KVStoreType} {
+    t.Run(storeType, func(t *testing.T) {
+      defer os.RemoveAll(keyStoreDir)
+      defer os.RemoveAll(blockStoreDir)
+      // Create and persist the genesis
+      gen, err := createGenesis()
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Open the block store of the selected type
+      blockStore, err := chain.OpenBlockStore(blockStoreDir, storeType)
+      if err != nil {
+        t.Fatal(err)
+      }
+      defer blockStore.Close()
+      // Create and persist several blocks to the block store
+      blks, err := createStoreBlocks(gen, 3, blockStore.WriteBlock)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that the blocks and txs are found by number and hash
+      verifyBlockStore(t, blockStore, blks)
+      // Read the encoded blocks starting from the second block
+      blocks, closeBlocks, err := blockStore.BlocksBytes(2)
+      if err != nil {
+        t.Fatal(err)
+      }
+      defer closeBlocks()
+      got := 0
+      for err := range blocks {
+        if err != nil {
+          t.Fatal(err)
+        }
+        got++
+      }
+      // Verify that only the blocks after the requested block number are read
+      if got != len(blks) - 1 {
+        t.Errorf(
+          "invalid number of blocks: expected %v, got %v", len(blks) - 1, got,
+        )
+      }
+      // Truncate the block store after the first block
+      err = blockStore.Truncate(1)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that only the first block and its txs are found
+      verifyBlockStore(t, blockStore, blks[:1])
+      _, found, err := blockStore.FindBlock(blks[2].Hash().String())
+      if err != nil {
+        t.Fatal(err)
+      }
+      if found {
+        t.Errorf("truncated block is found")
+      }
+      // Re-write the truncated blocks and verify that the blocks are found
+      for _, blk := range blks[1:] {
+        err := blockStore.WriteBlock(blk)
+        if err != nil {
+          t.Fatal(err)
+        }
+      }
+      verifyBlockStore(t, blockStore, blks)
+    })
+  }
+}
@@ -0,0 +1,185 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/dustinxie/ecc"
+)
+
+const (
+  ConsensusPoA = "poa"
+  ConsensusBFT = "bft"
+  ConsensusPoW = "pow"
+)
+
+type VoteType uint64
+
+const (
+  Prevote VoteType = 1
+  Precommit VoteType = 2
+)
+
+func (t VoteType) String() string {
+  switch t {
+  case Prevote:
+    return "prevote"
+  case Precommit:
+    return "precommit"
+  default:
+    return "vote"
+  }
+}
+
+// Vote is the vote of the validator for the block at the block number in the
+// round. The vote for the zero block hash is the nil vote
+type Vote struct {
+  Type VoteType `json:"type"`
+  Number uint64 `json:"number"`
+  Round uint64 `json:"round"`
+  Block Hash `json:"block"`
+  Validator Address `json:"validator"`
+}
+
+func NewVote(
+  voteType VoteType, number, round uint64, block Hash, validator Address,
+) Vote {
+  return Vote{
+    Type: voteType, Number: number, Round: round, Block: block,
+    Validator: validator,
+  }
+}
+
+func (v Vote) Hash() Hash {
+  return NewHash(v)
+}
+
+type SigVote struct {
+  Vote
+  Sig []byte `json:"sig"`
+}
+
+func NewSigVote(vote Vote, sig []byte) SigVote {
+  return SigVote{Vote: vote, Sig: sig}
+}
+
+func (v SigVote) String() string {
+  return fmt.Sprintf(
+    "%v %7d/%d: %.7s by %.7s", v.Type, v.Number, v.Round, v.Block, v.Validator,
+  )
+}
+
+func VerifyVote(vote SigVote) (bool, error) {
+  hash := vote.Vote.Hash().Bytes()
+  pub, err := ecc.RecoverPubkey("P-256k1", hash, vote.Sig)
+  if err != nil {
+    return false, err
+  }
+  acc := NewAddress(pub)
+  return acc == vote.Validator, nil
+}
+
+// Proposal proposes the block in the round. The block proposed in an earlier
+// round is re-proposed in the later round by the proposer of the later round
+type Proposal struct {
+  Round uint64 `json:"round"`
+  Block SigBlock `json:"block"`
+  Proposer Address `json:"proposer"`
+}
+
+func NewProposal(round uint64, block SigBlock, proposer Address) Proposal {
+  return Proposal{Round: round, Block: block, Proposer: proposer}
+}
+
+func (p Proposal) Hash() Hash {
+  return keccak(p.Encode())
+}
+
+type SigProposal struct {
+  Proposal
+  Sig []byte `json:"sig"`
+}
+
+func NewSigProposal(prop Proposal, sig []byte) SigProposal {
+  return SigProposal{Proposal: prop, Sig: sig}
+}
+
+// VerifyProposal verifies that the proposal is signed by the proposer in turn
+// in the round, and that the proposed block is not from a later round
+func VerifyProposal(prop SigProposal, validators []Address) error {
+  blk := prop.Block
+  hash := prop.Proposal.Hash().Bytes()
+  pub, err := ecc.RecoverPubkey("P-256k1", hash, prop.Sig)
+  if err != nil {
+    return err
+  }
+  if NewAddress(pub) != prop.Proposer {
+    return fmt.Errorf("blk error: invalid proposal signature\n%v", blk)
+  }
+  if prop.Proposer != Proposer(validators, blk.Number, prop.Round) {
+    return fmt.Errorf("blk error: proposer out of turn\n%v", blk)
+  }
+  if blk.Round > prop.Round {
+    return fmt.Errorf("blk error: block round after proposal round\n%v", blk)
+  }
+  return nil
+}
+
+// ConsensusMsg is either the block proposal or the validator vote
+type ConsensusMsg struct {
+  Proposal *SigProposal `json:"proposal,omitempty"`
+  Vote *SigVote `json:"vote,omitempty"`
+}
+
+// Commit holds the precommits of more than 2/3 of the validators for the block
+// in the round
+type Commit struct {
+  Round uint64 `json:"round"`
+  Precommits []SigVote `json:"precommits"`
+}
+
+// Quorum returns the minimal voting power that is more than 2/3 of the total
+// voting power of the validators
+func Quorum(power uint64) uint64 {
+  return power * 2 / 3 + 1
+}
+
+// VerifyCommit verifies that the validators with more than 2/3 of the total
+// voting power precommitted the block in the round not before the block round
+func VerifyCommit(blk SigBlock, validators []Validator) error {
+  if blk.Commit == nil {
+    return fmt.Errorf("blk error: missing commit\n%v", blk)
+  }
+  if blk.Round > blk.Commit.Round {
+    return fmt.Errorf("blk error: block round after commit round\n%v", blk)
+  }
+  hash := blk.Hash()
+  signed := make(map[Address]bool, len(blk.Commit.Precommits))
+  for _, vote := range blk.Commit.Precommits {
+    if vote.Type != Precommit || vote.Number != blk.Number ||
+      vote.Round != blk.Commit.Round || vote.Block != hash ||
+      validatorIndex(validators, vote.Validator) == -1 {
+      return fmt.Errorf("blk error: invalid precommit %v\n%v", vote, blk)
+    }
+    valid, err := VerifyVote(vote)
+    if err != nil {
+      return err
+    }
+    if !valid {
+      return fmt.Errorf("blk error: invalid precommit signature\n%v", blk)
+    }
+    signed[vote.Validator] = true
+  }
+  var power uint64
+  for _, val := range validators {
+    if signed[val.Address] {
+      power += val.Power
+    }
+  }
+  total := TotalPower(validators)
+  if power < Quorum(total) {
+    return fmt.Errorf(
+      "blk error: insufficient precommits %v of %v\n%v", power, total, blk,
+    )
+  }
+  return nil
+}
@@ -0,0 +1,86 @@
+package chain_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestVerifyProposal(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  // Create the validator accounts and the initial owner account
+  vals := make([]chain.Account, 3)
+  for i := range vals {
+    val, err := createAccount()
+    if err != nil {
+      t.Fatal(err)
+    }
+    vals[i] = val
+  }
+  acc, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the genesis with the ordered validator set
+  gen := chain.NewGenesis(
+    chainName, vals[0].Address(), acc.Address(), ownerBalance,
+    vals[1].Address(), vals[2].Address(),
+  )
+  gen.Consensus = chain.ConsensusBFT
+  sgen, err := vals[0].SignGen(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  validators := sgen.ValidatorSet()
+  // Create the blocks of the first two proposers in the first two rounds
+  state := chain.NewState(sgen)
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blk0, err := state.Clone().CreateBlock(vals[0], 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blk1, err := state.Clone().CreateBlock(vals[1], 1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  cases := []struct{
+    name string
+    signer chain.Account
+    proposer chain.Address
+    round uint64
+    blk chain.SigBlock
+    valid bool
+  }{
+    {"proposer in turn", vals[0], vals[0].Address(), 0, blk0, true},
+    {"block re-proposed in later round", vals[1], vals[1].Address(), 1,
+      blk0, true},
+    {"proposer out of turn", vals[1], vals[1].Address(), 0, blk0, false},
+    {"forged proposer", vals[1], vals[0].Address(), 0, blk0, false},
+    {"block from later round", vals[0], vals[0].Address(), 0, blk1, false},
+  }
+  for _, c := range cases {
+    // Sign the proposal of the block in the round
+    prop := chain.NewProposal(c.round, c.blk, c.proposer)
+    sprop, err := c.signer.SignProposal(prop)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that only the proposal of the proposer in turn is accepted
+    err = chain.VerifyProposal(sprop, validators)
+    if c.valid && err != nil {
+      t.Errorf("%v: proposal rejected: %v", c.name, err)
+    }
+    if !c.valid && err == nil {
+      t.Errorf("%v: proposal accepted", c.name)
+    }
+  }
+}
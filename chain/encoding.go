@@ -0,0 +1,257 @@
+package chain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// The encoding version of the txs, blocks, and genesis. The legacy encoding
+// hashes the JSON encoding of the value with the fields of the value before
+// the encoding versions, and pairs the Merkle tree hashes as the JSON string of
+// the hex hashes. The canonical encoding hashes the canonical binary encoding
+// of the value, which does not depend on the JSON encoder, the time
+// formatting, or the map ordering
+const (
+  LegacyEncoding uint64 = 0
+  CanonicalEncoding uint64 = 1
+  // EncodingVersion is the encoding version of the newly created values
+  EncodingVersion = CanonicalEncoding
+)
+
+// The type tag is the first byte of the canonical encoding that separates the
+// encodings of different types
+const (
+  tagTx byte = 0x01
+  tagSigTx byte = 0x02
+  tagBlock byte = 0x03
+  tagSigBlock byte = 0x04
+  tagGenesis byte = 0x05
+  tagSigGenesis byte = 0x06
+  tagAccState byte = 0x07
+  tagPair byte = 0x08
+  tagProposal byte = 0x09
+)
+
+// encoder writes the canonical binary encoding:
+//   - uint64 as 8 bytes big-endian
+//   - bool as uint64 0 or 1
+//   - bytes, string, and address as the uint64 length followed by the bytes
+//   - hash as 32 bytes
+//   - time as the uint64 of the Unix time in nanoseconds
+//   - list as the uint64 number of items followed by the items
+//   - map as the list of the key-value pairs ordered by the key
+//   - optional struct as the bool presence followed by the struct fields
+type encoder struct {
+  buf []byte
+}
+
+func newEncoder(tag byte) *encoder {
+  return &encoder{buf: []byte{tag}}
+}
+
+func (e *encoder) uint64(val uint64) {
+  e.buf = binary.BigEndian.AppendUint64(e.buf, val)
+}
+
+func (e *encoder) bool(val bool) {
+  if val {
+    e.uint64(1)
+  } else {
+    e.uint64(0)
+  }
+}
+
+func (e *encoder) bytes(val []byte) {
+  e.uint64(uint64(len(val)))
+  e.buf = append(e.buf, val...)
+}
+
+func (e *encoder) string(val string) {
+  e.bytes([]byte(val))
+}
+
+func (e *encoder) hash(val Hash) {
+  e.buf = append(e.buf, val[:]...)
+}
+
+// verifyVersion verifies the encoding version of the tx or the block. The
+// chain with the canonical genesis rejects the legacy encoding. The chain with
+// the legacy genesis accepts both encodings, so the existing chain migrates to
+// the canonical encoding as the upgraded nodes create new txs and blocks
+func (s *State) verifyVersion(version uint64) error {
+  if version > EncodingVersion {
+    return fmt.Errorf("unsupported encoding version %v", version)
+  }
+  if version < s.version {
+    return fmt.Errorf("legacy encoding version %v", version)
+  }
+  return nil
+}
+
+func keccak(val []byte) Hash {
+  state := sha3.NewLegacyKeccak256()
+  _, _ = state.Write(val)
+  return Hash(state.Sum(nil))
+}
+
+// Encode returns the canonical encoding of the tx: version, chain, kind, from,
+// to, value, fee, power, nonce, time, optional expiry of number and time
+func (t Tx) Encode() []byte {
+  enc := newEncoder(tagTx)
+  enc.uint64(t.Version)
+  enc.string(t.Chain)
+  enc.uint64(uint64(t.Kind))
+  enc.string(string(t.From))
+  enc.string(string(t.To))
+  enc.uint64(t.Value)
+  enc.uint64(t.Fee)
+  enc.uint64(t.Power)
+  enc.uint64(t.Nonce)
+  enc.uint64(uint64(t.Time.UnixNano()))
+  enc.bool(t.Expiry != nil)
+  if t.Expiry != nil {
+    enc.uint64(t.Expiry.Number)
+    var expTime uint64
+    if !t.Expiry.Time.IsZero() {
+      expTime = uint64(t.Expiry.Time.UnixNano())
+    }
+    enc.uint64(expTime)
+  }
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the signed tx: tx, signature,
+// approvals of validator and signature
+func (t SigTx) Encode() []byte {
+  enc := newEncoder(tagSigTx)
+  enc.bytes(t.Tx.Encode())
+  enc.bytes(t.Sig)
+  enc.uint64(uint64(len(t.Approvals)))
+  for _, appr := range t.Approvals {
+    enc.string(string(appr.Validator))
+    enc.bytes(appr.Sig)
+  }
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the block header: version, number,
+// parent, Merkle root, state root, round, nonce, difficulty, total work, time,
+// evidence of the two conflicting block hashes. The txs are committed by the
+// Merkle root
+func (b Block) Encode() []byte {
+  enc := newEncoder(tagBlock)
+  enc.uint64(b.Version)
+  enc.uint64(b.Number)
+  enc.hash(b.Parent)
+  enc.hash(b.MerkleRoot)
+  enc.hash(b.StateRoot)
+  enc.uint64(b.Round)
+  enc.uint64(b.Nonce)
+  enc.uint64(b.Difficulty)
+  enc.uint64(b.TotalWork)
+  enc.uint64(uint64(b.Time.UnixNano()))
+  enc.uint64(uint64(len(b.Evidence)))
+  for _, ev := range b.Evidence {
+    enc.hash(ev.Block1.Hash())
+    enc.hash(ev.Block2.Hash())
+  }
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the signed block: block, signature.
+// The commit is excluded as the validators vote for the block hash before the
+// block is committed
+func (b SigBlock) Encode() []byte {
+  enc := newEncoder(tagSigBlock)
+  enc.bytes(b.Block.Encode())
+  enc.bytes(b.Sig)
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the genesis: version, chain,
+// authority, validators, consensus, optional proof of work of difficulty and
+// block time in nanoseconds, optional block limits of max txs and max bytes,
+// balances of account and balance, time
+func (g Genesis) Encode() []byte {
+  enc := newEncoder(tagGenesis)
+  enc.uint64(g.Version)
+  enc.string(g.Chain)
+  enc.string(string(g.Authority))
+  enc.uint64(uint64(len(g.Validators)))
+  for _, val := range g.Validators {
+    enc.string(string(val))
+  }
+  enc.string(g.Consensus)
+  enc.bool(g.PoW != nil)
+  if g.PoW != nil {
+    enc.uint64(g.PoW.Difficulty)
+    enc.uint64(uint64(g.PoW.BlockTime))
+  }
+  enc.bool(g.Limits != nil)
+  if g.Limits != nil {
+    enc.uint64(g.Limits.MaxTxs)
+    enc.uint64(g.Limits.MaxBytes)
+  }
+  accs := slices.Sorted(maps.Keys(g.Balances))
+  enc.uint64(uint64(len(accs)))
+  for _, acc := range accs {
+    enc.string(string(acc))
+    enc.uint64(g.Balances[acc])
+  }
+  enc.uint64(uint64(g.Time.UnixNano()))
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the signed genesis: genesis,
+// signature
+func (g SigGenesis) Encode() []byte {
+  enc := newEncoder(tagSigGenesis)
+  enc.bytes(g.Genesis.Encode())
+  enc.bytes(g.Sig)
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the block proposal: round, block
+// hash, proposer
+func (p Proposal) Encode() []byte {
+  enc := newEncoder(tagProposal)
+  enc.uint64(p.Round)
+  enc.hash(p.Block.Hash())
+  enc.string(string(p.Proposer))
+  return enc.buf
+}
+
+// Encode returns the canonical encoding of the account state: account,
+// balance, nonce
+func (a AccState) Encode() []byte {
+  enc := newEncoder(tagAccState)
+  enc.string(string(a.Account))
+  enc.uint64(a.Balance)
+  enc.uint64(a.Nonce)
+  return enc.buf
+}
+
+// PairHash hashes the canonical encoding of the pair of the Merkle tree
+// hashes. The left hash without the right hash is promoted as is
+func PairHash(l, r Hash) Hash {
+  var nilHash Hash
+  if r == nilHash {
+    return l
+  }
+  enc := newEncoder(tagPair)
+  enc.hash(l)
+  enc.hash(r)
+  return keccak(enc.buf)
+}
+
+// MerklePairHash returns the Merkle tree pair hash of the encoding version
+func MerklePairHash(version uint64) func(l, r Hash) Hash {
+  if version == LegacyEncoding {
+    return TxPairHash
+  }
+  return PairHash
+}
@@ -0,0 +1,172 @@
+package chain_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestCanonicalEncoding(t *testing.T) {
+  tx := chain.Tx{
+    Version: chain.CanonicalEncoding, Chain: "blockchain",
+    From: chain.Address("from"), To: chain.Address("to"),
+    Value: 12, Fee: 1, Nonce: 1, Time: time.Unix(0, 1700000000123456789),
+  }
+  t.Run("golden tx encoding", func(t *testing.T) {
+    // Verify that the canonical encoding and the hash of the tx match the
+    // reference values for the non-Go tooling
+    expEnc := "01" + "0000000000000001" +
+      "000000000000000a" + hex.EncodeToString([]byte("blockchain")) +
+      "0000000000000000" +
+      "0000000000000004" + hex.EncodeToString([]byte("from")) +
+      "0000000000000002" + hex.EncodeToString([]byte("to")) +
+      "000000000000000c" + "0000000000000001" + "0000000000000000" +
+      "0000000000000001" + "17979cfe3d85cd15" + "0000000000000000"
+    gotEnc := hex.EncodeToString(tx.Encode())
+    if gotEnc != expEnc {
+      t.Errorf("invalid tx encoding: expected %v, got %v", expEnc, gotEnc)
+    }
+    expHash :=
+      "8a2cd67412c6315b5938c47698d9c207c705ba85646407fb0f5a46afab940ad7"
+    if tx.Hash().String() != expHash {
+      t.Errorf("invalid tx hash: expected %v, got %v", expHash, tx.Hash())
+    }
+  })
+  t.Run("hash independent of time zone and json", func(t *testing.T) {
+    // Verify that the tx hash does not depend on the time zone of the tx time
+    zoneTx := tx
+    zoneTx.Time = tx.Time.In(time.FixedZone("UTC+1", 3600))
+    if zoneTx.Hash() != tx.Hash() {
+      t.Errorf("invalid tx hash: time zone changes tx hash")
+    }
+    // Verify that the tx hash is preserved over the JSON round trip
+    jtx, err := json.Marshal(zoneTx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    var jsonTx chain.Tx
+    err = json.Unmarshal(jtx, &jsonTx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if jsonTx.Hash() != tx.Hash() {
+      t.Errorf("invalid tx hash: JSON round trip changes tx hash")
+    }
+  })
+  t.Run("legacy hashes of existing chain", func(t *testing.T) {
+    // Re-create the tx and the block of the chain before the encoding versions
+    legacyTx := chain.Tx{
+      From: chain.Address("from"), To: chain.Address("to"), Value: 12,
+      Nonce: 1, Time: time.Unix(0, 1700000000123456789).UTC(),
+    }
+    stx := chain.NewSigTx(legacyTx, []byte("sig"))
+    blk := chain.Block{
+      Number: 1, Txs: []chain.SigTx{stx}, MerkleRoot: stx.Hash(),
+      Time: time.Unix(0, 1700000001123456789).UTC(),
+    }
+    sblk := chain.NewSigBlock(blk, []byte("sig"))
+    stx2 := chain.NewSigTx(chain.Tx{
+      From: chain.Address("a"), To: chain.Address("b"), Value: 1, Nonce: 2,
+      Time: legacyTx.Time,
+    }, []byte("sig2"))
+    merkleTree, err := chain.MerkleHash(
+      []chain.SigTx{stx, stx2}, chain.TxHash,
+      chain.MerklePairHash(chain.LegacyEncoding),
+    )
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the legacy hashes match the hashes of the existing chain
+    cases := []struct{ name string; got chain.Hash; exp string }{
+      {
+        "tx", legacyTx.Hash(),
+        "724f2c3b5a3bd4844c1e8d82cd2a4de2e11a7367971ab8c93b6594f98cc83cd0",
+      },
+      {
+        "signed tx", stx.Hash(),
+        "9ddcce00ed7c646fc56c1d3b51910031580b62cb96541a99d18775e370f6d47d",
+      },
+      {
+        "block", blk.Hash(),
+        "96849683009d00e82e3ead330128f944b43bea9c7be6505acdab738259674031",
+      },
+      {
+        "signed block", sblk.Hash(),
+        "c77f6e72877d0d608823d662567dc61afc0c655d5a79fd724c1e645b957cf806",
+      },
+      {
+        "Merkle root", merkleTree[0],
+        "9a37205b1c4faf6d6fd97891d687c5a3f248b33a8b34b0b4b4764ff40bc1b750",
+      },
+    }
+    for _, c := range cases {
+      if c.got.String() != c.exp {
+        t.Errorf(
+          "invalid legacy %v hash: expected %v, got %v", c.name, c.exp, c.got,
+        )
+      }
+    }
+  })
+}
+
+func TestEncodingVersion(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis with the canonical encoding
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path := filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create and sign the tx with the legacy encoding
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  tx.Version = chain.LegacyEncoding
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the chain with the canonical genesis rejects the legacy tx
+  state := chain.NewState(gen)
+  err = state.Pending.ApplyTx(stx)
+  if err == nil {
+    t.Errorf("legacy tx applied to canonical chain")
+  }
+  // Verify that the chain with the legacy genesis accepts both the legacy tx
+  // and the canonical tx
+  gen.Version = chain.LegacyEncoding
+  state = chain.NewState(gen)
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  tx = chain.NewTx(chainName, acc.Address(), chain.Address("to"), 34, 2)
+  stx, err = acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the legacy chain accepts the legacy tx without the chain name
+  tx = chain.NewTx("", acc.Address(), chain.Address("to"), 56, 3)
+  tx.Version = chain.LegacyEncoding
+  stx, err = acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+}
@@ -0,0 +1,134 @@
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dustinxie/ecc"
+)
+
+// The offending validator is slashed by 1/slashFraction of the balance
+const slashFraction = 10
+
+// Evidence proves the equivocation of the validator that signed two conflicting
+// blocks at the same block number in any rounds
+type Evidence struct {
+  Block1 SigBlock `json:"block1"`
+  Block2 SigBlock `json:"block2"`
+}
+
+// NewEvidence orders the conflicting blocks by the block hash, so the same
+// equivocation always results in the same evidence
+func NewEvidence(blk1, blk2 SigBlock) Evidence {
+  blk1.Commit, blk2.Commit = nil, nil
+  hash1, hash2 := blk1.Hash(), blk2.Hash()
+  if bytes.Compare(hash1[:], hash2[:]) > 0 {
+    blk1, blk2 = blk2, blk1
+  }
+  return Evidence{Block1: blk1, Block2: blk2}
+}
+
+func (e Evidence) Hash() Hash {
+  return NewHash(e)
+}
+
+func (e Evidence) String() string {
+  return fmt.Sprintf(
+    "evd %.7s: %7d/%d   %.7s <> %.7s",
+    e.Hash(), e.Block1.Number, e.Block1.Round,
+    e.Block1.Hash(), e.Block2.Hash(),
+  )
+}
+
+// BlockSigner recovers the address of the validator that signed the block
+func BlockSigner(blk SigBlock) (Address, error) {
+  hash := blk.Block.Hash().Bytes()
+  pub, err := ecc.RecoverPubkey("P-256k1", hash, blk.Sig)
+  if err != nil {
+    return "", err
+  }
+  return NewAddress(pub), nil
+}
+
+// VerifyEvidence verifies that the same validator signed two different blocks
+// at the same block number regardless of the round and returns the offending
+// validator. The honest validator re-proposes the same block in a later round
+func VerifyEvidence(ev Evidence) (Address, error) {
+  blk1, blk2 := ev.Block1, ev.Block2
+  if blk1.Number != blk2.Number || blk1.Block.Hash() == blk2.Block.Hash() {
+    return "", fmt.Errorf("evd error: blocks do not conflict\n%v\n", ev)
+  }
+  signer1, err := BlockSigner(blk1)
+  if err != nil {
+    return "", err
+  }
+  signer2, err := BlockSigner(blk2)
+  if err != nil {
+    return "", err
+  }
+  if signer1 != signer2 {
+    return "", fmt.Errorf("evd error: blocks of different signers\n%v\n", ev)
+  }
+  return signer1, nil
+}
+
+// penalize jails the offending validator by removing the validator from the
+// validator set and slashes the balance of the offending validator. The last
+// validator is jailed and slashed, but stays in the validator set to keep the
+// chain live
+func (s *State) penalize(ev Evidence) error {
+  if s.consensus == ConsensusPoW {
+    return fmt.Errorf("evd error: evidence in proof of work\n%v\n", ev)
+  }
+  offender, err := VerifyEvidence(ev)
+  if err != nil {
+    return err
+  }
+  _, jailed := s.jailed[offender]
+  if jailed {
+    return fmt.Errorf("evd error: validator already jailed\n%v\n", ev)
+  }
+  i := validatorIndex(s.validators, offender)
+  if i == -1 {
+    return fmt.Errorf("evd error: offender is not a validator\n%v\n", ev)
+  }
+  if s.jailed == nil {
+    s.jailed = make(map[Address]uint64)
+  }
+  s.jailed[offender] = ev.Block1.Number
+  if len(s.validators) > 1 {
+    s.validators = slices.Delete(slices.Clone(s.validators), i, i + 1)
+  }
+  s.balances[offender] -= s.balances[offender] / slashFraction
+  s.touch(offender)
+  return nil
+}
+
+// ApplyEvidence verifies and penalizes the equivocation on the pending state.
+// The pending evidence is included in the next block
+func (s *State) ApplyEvidence(ev Evidence) error {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  _, exist := s.evidence[ev.Hash()]
+  if exist {
+    return fmt.Errorf("evd error: evidence already pending\n%v\n", ev)
+  }
+  err := s.penalize(ev)
+  if err != nil {
+    return err
+  }
+  s.evidence[ev.Hash()] = ev
+  return nil
+}
+
+// pendingEvidence returns the pending evidence ordered by the evidence hash
+func (s *State) pendingEvidence() []Evidence {
+  evs := slices.Collect(maps.Values(s.Pending.evidence))
+  slices.SortFunc(evs, func(a, b Evidence) int {
+    ha, hb := a.Hash(), b.Hash()
+    return bytes.Compare(ha[:], hb[:])
+  })
+  return evs
+}
@@ -0,0 +1,137 @@
+package chain_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestEvidence(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  // Create the validator accounts. The first validator holds the initial
+  // balance of the blockchain
+  vals := make([]chain.Account, 3)
+  for i := range vals {
+    val, err := createAccount()
+    if err != nil {
+      t.Fatal(err)
+    }
+    vals[i] = val
+  }
+  acc := vals[0]
+  gen := chain.NewGenesis(
+    chainName, acc.Address(), acc.Address(), ownerBalance,
+    vals[1].Address(), vals[2].Address(),
+  )
+  // Start the genesis one slot timeout earlier to let the next validator take
+  // over the missed slot in the next round
+  gen.Time = gen.Time.Add(-chain.SlotTimeout)
+  sgen, err := acc.SignGen(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(sgen)
+  // Create and apply a transaction to the pending state
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  createBlock := func(validator chain.Account, round uint64) chain.SigBlock {
+    clone := state.Clone()
+    blk, err := clone.CreateBlock(validator, round)
+    if err != nil {
+      t.Fatal(err)
+    }
+    return blk
+  }
+  // Create two conflicting blocks signed by the first validator at the same
+  // block number in the same round
+  blk1, blk2 := createBlock(acc, 0), createBlock(acc, 0)
+  ev := chain.NewEvidence(blk1, blk2)
+  t.Run("verify evidence", func(t *testing.T) {
+    // Verify that the offending validator is recovered from the evidence
+    offender, err := chain.VerifyEvidence(ev)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if offender != acc.Address() {
+      t.Errorf(
+        "invalid offender: expected %v, got %v", acc.Address(), offender,
+      )
+    }
+    // Verify that the evidence does not depend on the order of the blocks
+    if chain.NewEvidence(blk2, blk1).Hash() != ev.Hash() {
+      t.Errorf("invalid evidence: order of blocks changes evidence hash")
+    }
+    // Verify that the same block is not an equivocation
+    _, err = chain.VerifyEvidence(chain.NewEvidence(blk1, blk1))
+    if err == nil {
+      t.Errorf("same block evidence verified")
+    }
+    // Verify that the blocks of the same validator in different rounds are an
+    // equivocation
+    blk4 := createBlock(acc, 1)
+    offender, err = chain.VerifyEvidence(chain.NewEvidence(blk1, blk4))
+    if err != nil {
+      t.Fatal(err)
+    }
+    if offender != acc.Address() {
+      t.Errorf(
+        "invalid offender: expected %v, got %v", acc.Address(), offender,
+      )
+    }
+    // Verify that the blocks of different validators are not an equivocation
+    blk3 := createBlock(vals[1], 0)
+    _, err = chain.VerifyEvidence(chain.NewEvidence(blk1, blk3))
+    if err == nil {
+      t.Errorf("different validators evidence verified")
+    }
+  })
+  t.Run("jail and slash offender", func(t *testing.T) {
+    // Apply the evidence to the pending state
+    err := state.Pending.ApplyEvidence(ev)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the duplicated evidence is rejected
+    err = state.Pending.ApplyEvidence(ev)
+    if err == nil {
+      t.Errorf("duplicated evidence applied")
+    }
+    // Create the block with the evidence by the next validator in the next
+    // round
+    blk := createBlock(vals[1], 1)
+    if !slices.ContainsFunc(blk.Evidence, func(e chain.Evidence) bool {
+      return e.Hash() == ev.Hash()
+    }) {
+      t.Fatalf("evidence is not included in the block")
+    }
+    // Apply the block with the evidence to the confirmed state
+    err = state.ApplyBlockToState(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the offending validator is removed from the validator set
+    if slices.Contains(state.Validators(), acc.Address()) {
+      t.Errorf("jailed validator is in the validator set")
+    }
+    // Verify that the balance of the offending validator is slashed
+    balance, _ := state.Balance(acc.Address())
+    exp := uint64(ownerBalance - 12) - uint64(ownerBalance - 12) / 10
+    if balance != exp {
+      t.Errorf("invalid balance: expected %v, got %v", exp, balance)
+    }
+    // Verify that the offending validator is not penalized twice
+    err = state.Pending.ApplyEvidence(ev)
+    if err == nil {
+      t.Errorf("evidence against jailed validator applied")
+    }
+  })
+}
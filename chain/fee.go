@@ -0,0 +1,113 @@
+package chain
+
+import (
+	"bytes"
+	"cmp"
+	"container/heap"
+	"math/bits"
+	"slices"
+)
+
+// TxSize returns the size of the canonical encoding of the signed tx in bytes
+func TxSize(tx SigTx) uint64 {
+  return uint64(len(tx.Encode()))
+}
+
+func feeRateCmp(feeA, sizeA, feeB, sizeB uint64) int {
+  hiA, loA := bits.Mul64(feeA, sizeB)
+  hiB, loB := bits.Mul64(feeB, sizeA)
+  if hiA != hiB {
+    return cmp.Compare(hiA, hiB)
+  }
+  return cmp.Compare(loA, loB)
+}
+
+// FeeRateCmp compares the fee per byte of the txs. The fee rates are compared
+// by the cross multiplication of the fees and the sizes without the loss of
+// precision
+func FeeRateCmp(a, b SigTx) int {
+  return feeRateCmp(a.Fee, TxSize(a), b.Fee, TxSize(b))
+}
+
+// feeTx is the pending tx with the size and the hash computed once for the
+// ordering of the txs
+type feeTx struct {
+  tx SigTx
+  size uint64
+  hash Hash
+}
+
+// priorityCmp orders the txs for the inclusion in the block. The tx with the
+// higher fee per byte goes first, then the earlier tx, then the tx with the
+// lower hash
+func priorityCmp(a, b feeTx) int {
+  c := feeRateCmp(b.tx.Fee, b.size, a.tx.Fee, a.size)
+  if c != 0 {
+    return c
+  }
+  c = a.tx.Time.Compare(b.tx.Time)
+  if c != 0 {
+    return c
+  }
+  return bytes.Compare(a.hash[:], b.hash[:])
+}
+
+// feeHeap holds the next tx of every account ordered by the priority
+type feeHeap [][]feeTx
+
+func (h feeHeap) Len() int {
+  return len(h)
+}
+
+func (h feeHeap) Less(i, j int) bool {
+  return priorityCmp(h[i][0], h[j][0]) < 0
+}
+
+func (h feeHeap) Swap(i, j int) {
+  h[i], h[j] = h[j], h[i]
+}
+
+func (h *feeHeap) Push(txs any) {
+  *h = append(*h, txs.([]feeTx))
+}
+
+func (h *feeHeap) Pop() any {
+  old := *h
+  txs := old[len(old) - 1]
+  *h = old[:len(old) - 1]
+  return txs
+}
+
+// feeOrder orders the pending txs by the fee per byte while keeping the txs of
+// every account in the nonce order. The next tx of every account competes for
+// the next position in the block
+func feeOrder(txs []SigTx) []feeTx {
+  accTxs := make(map[Address][]feeTx)
+  for _, tx := range txs {
+    ftx := feeTx{tx: tx, size: TxSize(tx), hash: tx.Hash()}
+    accTxs[tx.From] = append(accTxs[tx.From], ftx)
+  }
+  h := make(feeHeap, 0, len(accTxs))
+  for _, txs := range accTxs {
+    slices.SortFunc(txs, func(a, b feeTx) int {
+      if a.tx.Nonce != b.tx.Nonce {
+        return cmp.Compare(a.tx.Nonce, b.tx.Nonce)
+      }
+      return priorityCmp(a, b)
+    })
+    h = append(h, txs)
+  }
+  heap.Init(&h)
+  ordered := make([]feeTx, 0, len(txs))
+  for h.Len() > 0 {
+    txs := h[0]
+    ordered = append(ordered, txs[0])
+    if len(txs) == 1 {
+      heap.Pop(&h)
+      continue
+    }
+    h[0] = txs[1:]
+    heap.Fix(&h, 0)
+  }
+  return ordered
+}
@@ -0,0 +1,99 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestFeeOrder(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, ownerBal := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  owner, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  acc, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(gen)
+  applyTx := func(from chain.Account, to chain.Address, value, fee uint64) {
+    tx := chain.NewTx(
+      chainName, from.Address(), to, value,
+      state.Pending.Nonce(from.Address()) + 1,
+    )
+    tx.Fee = fee
+    stx, err := from.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.Pending.ApplyTx(stx)
+    if err != nil {
+      t.Fatal(err)
+    }
+  }
+  applyBlock := func() chain.SigBlock {
+    clone := state.Clone()
+    blk, err := clone.CreateBlock(auth, 0)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.ApplyBlockToState(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    return blk
+  }
+  // Fund the second account
+  applyTx(owner, acc.Address(), 100, 0)
+  applyBlock()
+  // Create the txs of both accounts with different fees. The owner tx with the
+  // highest fee has the highest nonce
+  applyTx(owner, chain.Address("to"), 1, 1)
+  applyTx(owner, chain.Address("to"), 2, 10)
+  applyTx(acc, chain.Address("to"), 3, 5)
+  blk := applyBlock()
+  // Verify that the txs are ordered by the fee per byte while keeping the
+  // nonce order of the owner txs
+  expValues := []uint64{3, 1, 2}
+  if len(blk.Txs) != len(expValues) {
+    t.Fatalf(
+      "invalid number of txs: expected %v, got %v", len(expValues),
+      len(blk.Txs),
+    )
+  }
+  for i, tx := range blk.Txs {
+    if tx.Value != expValues[i] {
+      t.Errorf("invalid tx order: expected %v, got %v", expValues[i], tx.Value)
+    }
+  }
+  // Verify that the fees are deducted from the senders and credited to the
+  // block proposer
+  cases := []struct{ acc chain.Address; exp uint64 }{
+    {owner.Address(), ownerBal - 100 - 1 - 1 - 2 - 10},
+    {acc.Address(), 100 - 3 - 5},
+    {auth.Address(), 1 + 10 + 5},
+  }
+  for _, c := range cases {
+    got, _ := state.Balance(c.acc)
+    if got != c.exp {
+      t.Errorf("invalid balance: expected %v, got %v", c.exp, got)
+    }
+  }
+}
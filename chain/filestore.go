@@ -0,0 +1,569 @@
+package chain
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+  blockIdxFile = "block.idx"
+  hashIdxFile = "hash.idx"
+  txIdxFile = "tx.idx"
+  accIdxFile = "acc.idx"
+  // block.idx: the offset and the length of the block number - 1
+  blockIdxLen = 16
+  // hash.idx: the block hash and the block number
+  hashIdxLen = 40
+  // tx.idx: the tx hash, the block number, and the tx position in the block
+  txIdxLen = 48
+  // acc.idx: the account hash, the block number, and the tx position in the
+  // block for both the sender and the recipient of the tx
+  accIdxLen = 48
+)
+
+func accHash(acc Address) Hash {
+  return NewHash(acc)
+}
+
+func appendFile(path string, data []byte) error {
+  file, err := os.OpenFile(path, os.O_CREATE | os.O_APPEND | os.O_WRONLY, 0600)
+  if err != nil {
+    return err
+  }
+  defer file.Close()
+  _, err = file.Write(data)
+  return err
+}
+
+func blockIndexRecords(blk SigBlock, offset, length int64) (
+  []byte, []byte, []byte, []byte,
+) {
+  blkRec := make([]byte, blockIdxLen)
+  binary.BigEndian.PutUint64(blkRec[0:8], uint64(offset))
+  binary.BigEndian.PutUint64(blkRec[8:16], uint64(length))
+  hashRec := make([]byte, hashIdxLen)
+  copy(hashRec[0:32], blk.Hash().Bytes())
+  binary.BigEndian.PutUint64(hashRec[32:40], blk.Number)
+  txRecs := make([]byte, 0, txIdxLen * len(blk.Txs))
+  accRecs := make([]byte, 0, accIdxLen * len(blk.Txs) * 2)
+  for i, tx := range blk.Txs {
+    txRec := make([]byte, txIdxLen)
+    copy(txRec[0:32], tx.Hash().Bytes())
+    binary.BigEndian.PutUint64(txRec[32:40], blk.Number)
+    binary.BigEndian.PutUint64(txRec[40:48], uint64(i))
+    txRecs = append(txRecs, txRec...)
+    accs := []Address{tx.From}
+    if tx.To != tx.From {
+      accs = append(accs, tx.To)
+    }
+    for _, acc := range accs {
+      accRec := make([]byte, accIdxLen)
+      copy(accRec[0:32], accHash(acc).Bytes())
+      copy(accRec[32:48], txRec[32:48])
+      accRecs = append(accRecs, accRec...)
+    }
+  }
+  return blkRec, hashRec, txRecs, accRecs
+}
+
+// writeBlockIndex appends the block index last, so an interrupted index write
+// is detected on the next open of the block store
+func writeBlockIndex(dir string, blk SigBlock, offset, length int64) error {
+  blkRec, hashRec, txRecs, accRecs := blockIndexRecords(blk, offset, length)
+  err := appendFile(filepath.Join(dir, txIdxFile), txRecs)
+  if err != nil {
+    return err
+  }
+  err = appendFile(filepath.Join(dir, accIdxFile), accRecs)
+  if err != nil {
+    return err
+  }
+  err = appendFile(filepath.Join(dir, hashIdxFile), hashRec)
+  if err != nil {
+    return err
+  }
+  return appendFile(filepath.Join(dir, blockIdxFile), blkRec)
+}
+
+type FileStore struct {
+  dir string
+  mtx sync.Mutex
+  hashes map[Hash]uint64
+  hashIdxOff int64
+  txs map[Hash]TxPos
+  txIdxOff int64
+  accs map[Hash][]TxPos
+  accIdxOff int64
+  recovery Recovery
+}
+
+func OpenFileStore(dir string) (*FileStore, error) {
+  err := InitBlockStore(dir)
+  if err != nil {
+    return nil, err
+  }
+  s := &FileStore{
+    dir: dir, hashes: make(map[Hash]uint64), txs: make(map[Hash]TxPos),
+    accs: make(map[Hash][]TxPos),
+  }
+  offset, err := s.indexedOffset()
+  if err != nil {
+    return nil, err
+  }
+  err = s.indexFrom(offset)
+  if err != nil {
+    return nil, err
+  }
+  err = s.refresh()
+  if err != nil {
+    return nil, err
+  }
+  return s, nil
+}
+
+func (s *FileStore) path(file string) string {
+  return filepath.Join(s.dir, file)
+}
+
+func (s *FileStore) WriteBlock(blk SigBlock) error {
+  return blk.Write(s.dir)
+}
+
+func (s *FileStore) Recovery() Recovery {
+  return s.recovery
+}
+
+func (s *FileStore) Close() error {
+  return nil
+}
+
+func fileSize(path string) (int64, bool, error) {
+  info, err := os.Stat(path)
+  if os.IsNotExist(err) {
+    return 0, false, nil
+  }
+  if err != nil {
+    return 0, false, err
+  }
+  return info.Size(), true, nil
+}
+
+// indexedOffset returns the block store offset up to which the indexes are
+// consistent with the block store. Inconsistent indexes are reset to be rebuilt
+// from the start of the block store
+func (s *FileStore) indexedOffset() (int64, error) {
+  storeSize, _, err := fileSize(s.path(blocksFile))
+  if err != nil {
+    return 0, err
+  }
+  blkIdxSize, blkExist, err := fileSize(s.path(blockIdxFile))
+  if err != nil {
+    return 0, err
+  }
+  hashIdxSize, hashExist, err := fileSize(s.path(hashIdxFile))
+  if err != nil {
+    return 0, err
+  }
+  txIdxSize, txExist, err := fileSize(s.path(txIdxFile))
+  if err != nil {
+    return 0, err
+  }
+  accIdxSize, accExist, err := fileSize(s.path(accIdxFile))
+  if err != nil {
+    return 0, err
+  }
+  valid := blkExist && hashExist && txExist && accExist &&
+    blkIdxSize % blockIdxLen == 0 && txIdxSize % txIdxLen == 0 &&
+    accIdxSize % accIdxLen == 0 &&
+    hashIdxSize == blkIdxSize / blockIdxLen * hashIdxLen
+  if valid && blkIdxSize > 0 {
+    offset, length, err := s.blockOffset(uint64(blkIdxSize / blockIdxLen))
+    if err != nil {
+      return 0, err
+    }
+    if offset + length <= storeSize {
+      return offset + length, nil
+    }
+  }
+  if valid && blkIdxSize == 0 {
+    return 0, nil
+  }
+  fmt.Printf("=== Block store: rebuilding indexes in %v\n", s.dir)
+  idxFiles := []string{blockIdxFile, hashIdxFile, txIdxFile, accIdxFile}
+  for _, file := range idxFiles {
+    err := os.WriteFile(s.path(file), nil, 0600)
+    if err != nil {
+      return 0, err
+    }
+  }
+  return 0, nil
+}
+
+// indexFrom indexes the block store records starting from the offset. The
+// block store is truncated at the first torn or corrupted record
+func (s *FileStore) indexFrom(offset int64) error {
+  file, err := os.OpenFile(s.path(blocksFile), os.O_RDWR, 0600)
+  if err != nil {
+    return err
+  }
+  defer file.Close()
+  info, err := file.Stat()
+  if err != nil {
+    return err
+  }
+  _, err = file.Seek(offset, io.SeekStart)
+  if err != nil {
+    return err
+  }
+  var blkRecs, hashRecs, txRecs, accRecs []byte
+  rd := bufio.NewReader(file)
+  for {
+    rec, err := rd.ReadBytes('\n')
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    jblk, err := decodeBlockRecord(rec)
+    if err != nil {
+      break
+    }
+    var blk SigBlock
+    err = json.Unmarshal(jblk, &blk)
+    if err != nil {
+      break
+    }
+    length := int64(len(rec))
+    blkRec, hashRec, txRec, accRec := blockIndexRecords(blk, offset, length)
+    blkRecs = append(blkRecs, blkRec...)
+    hashRecs = append(hashRecs, hashRec...)
+    txRecs = append(txRecs, txRec...)
+    accRecs = append(accRecs, accRec...)
+    offset += length
+  }
+  if info.Size() > offset {
+    err = file.Truncate(offset)
+    if err != nil {
+      return err
+    }
+    err = file.Sync()
+    if err != nil {
+      return err
+    }
+    s.recovery.DroppedBytes = info.Size() - offset
+  }
+  err = appendFile(s.path(txIdxFile), txRecs)
+  if err != nil {
+    return err
+  }
+  err = appendFile(s.path(accIdxFile), accRecs)
+  if err != nil {
+    return err
+  }
+  err = appendFile(s.path(hashIdxFile), hashRecs)
+  if err != nil {
+    return err
+  }
+  err = appendFile(s.path(blockIdxFile), blkRecs)
+  if err != nil {
+    return err
+  }
+  s.recovery.Height, err = s.Height()
+  return err
+}
+
+func readIndexTail(path string, offset int64) ([]byte, error) {
+  file, err := os.Open(path)
+  if err != nil {
+    return nil, err
+  }
+  defer file.Close()
+  _, err = file.Seek(offset, io.SeekStart)
+  if err != nil {
+    return nil, err
+  }
+  return io.ReadAll(file)
+}
+
+// refresh loads the index records appended by SigBlock.Write since the last
+// refresh. The caller must hold the lock or own the block store exclusively
+func (s *FileStore) refresh() error {
+  recs, err := readIndexTail(s.path(hashIdxFile), s.hashIdxOff)
+  if err != nil {
+    return err
+  }
+  for i := 0; i + hashIdxLen <= len(recs); i += hashIdxLen {
+    rec := recs[i:i + hashIdxLen]
+    s.hashes[Hash(rec[0:32])] = binary.BigEndian.Uint64(rec[32:40])
+    s.hashIdxOff += hashIdxLen
+  }
+  recs, err = readIndexTail(s.path(txIdxFile), s.txIdxOff)
+  if err != nil {
+    return err
+  }
+  for i := 0; i + txIdxLen <= len(recs); i += txIdxLen {
+    rec := recs[i:i + txIdxLen]
+    s.txs[Hash(rec[0:32])] = TxPos{
+      Number: binary.BigEndian.Uint64(rec[32:40]),
+      Index: binary.BigEndian.Uint64(rec[40:48]),
+    }
+    s.txIdxOff += txIdxLen
+  }
+  recs, err = readIndexTail(s.path(accIdxFile), s.accIdxOff)
+  if err != nil {
+    return err
+  }
+  for i := 0; i + accIdxLen <= len(recs); i += accIdxLen {
+    rec := recs[i:i + accIdxLen]
+    acc := Hash(rec[0:32])
+    pos := TxPos{
+      Number: binary.BigEndian.Uint64(rec[32:40]),
+      Index: binary.BigEndian.Uint64(rec[40:48]),
+    }
+    // Skip the duplicate records of an interrupted index write
+    poss := s.accs[acc]
+    if len(poss) == 0 || poss[len(poss) - 1].Less(pos) {
+      s.accs[acc] = append(poss, pos)
+    }
+    s.accIdxOff += accIdxLen
+  }
+  return nil
+}
+
+func (s *FileStore) blockOffset(number uint64) (int64, int64, error) {
+  if number == 0 {
+    return 0, 0, fmt.Errorf("block %v not found", number)
+  }
+  file, err := os.Open(s.path(blockIdxFile))
+  if err != nil {
+    return 0, 0, err
+  }
+  defer file.Close()
+  rec := make([]byte, blockIdxLen)
+  _, err = file.ReadAt(rec, int64(number - 1) * blockIdxLen)
+  if err == io.EOF {
+    return 0, 0, fmt.Errorf("block %v not found", number)
+  }
+  if err != nil {
+    return 0, 0, err
+  }
+  offset := int64(binary.BigEndian.Uint64(rec[0:8]))
+  length := int64(binary.BigEndian.Uint64(rec[8:16]))
+  return offset, length, nil
+}
+
+func (s *FileStore) Height() (uint64, error) {
+  size, _, err := fileSize(s.path(blockIdxFile))
+  if err != nil {
+    return 0, err
+  }
+  return uint64(size / blockIdxLen), nil
+}
+
+func (s *FileStore) BlockBytes(number uint64) ([]byte, error) {
+  offset, length, err := s.blockOffset(number)
+  if err != nil {
+    return nil, err
+  }
+  file, err := os.Open(s.path(blocksFile))
+  if err != nil {
+    return nil, err
+  }
+  defer file.Close()
+  rec := make([]byte, length)
+  _, err = file.ReadAt(rec, offset)
+  if err != nil {
+    return nil, err
+  }
+  return decodeBlockRecord(rec)
+}
+
+func (s *FileStore) Block(number uint64) (SigBlock, error) {
+  jblk, err := s.BlockBytes(number)
+  if err != nil {
+    return SigBlock{}, err
+  }
+  var blk SigBlock
+  err = json.Unmarshal(jblk, &blk)
+  return blk, err
+}
+
+func (s *FileStore) BlocksBytes(from uint64) (
+  func(yield func(err error, jblk []byte) bool), func(), error,
+) {
+  if from == 0 {
+    from = 1
+  }
+  height, err := s.Height()
+  if err != nil {
+    return nil, nil, err
+  }
+  var offset int64
+  if from <= height {
+    offset, _, err = s.blockOffset(from)
+  } else {
+    offset, _, err = fileSize(s.path(blocksFile))
+  }
+  if err != nil {
+    return nil, nil, err
+  }
+  file, err := os.Open(s.path(blocksFile))
+  if err != nil {
+    return nil, nil, err
+  }
+  close := func() {
+    file.Close()
+  }
+  _, err = file.Seek(offset, io.SeekStart)
+  if err != nil {
+    file.Close()
+    return nil, nil, err
+  }
+  blocks := func(yield func(err error, jblk []byte) bool) {
+    sca := bufio.NewScanner(file)
+    sca.Buffer(nil, 64 * 1024 * 1024)
+    more := true
+    for sca.Scan() && more {
+      jblk, err := decodeBlockRecord(sca.Bytes())
+      if err != nil {
+        yield(err, nil)
+        return
+      }
+      more = yield(nil, jblk)
+    }
+    err := sca.Err()
+    if err != nil && more {
+      yield(err, nil)
+    }
+  }
+  return blocks, close, nil
+}
+
+func findHash[V any](
+  idx map[Hash]V, prefix string,
+) (Hash, V, bool) {
+  prefix = strings.ToLower(prefix)
+  if len(prefix) == 2 * len(Hash{}) {
+    hash, err := DecodeHash(prefix)
+    if err == nil {
+      val, exist := idx[hash]
+      return hash, val, exist
+    }
+  }
+  var nilVal V
+  if len(prefix) == 0 {
+    return Hash{}, nilVal, false
+  }
+  for hash, val := range idx {
+    if strings.HasPrefix(hex.EncodeToString(hash[:]), prefix) {
+      return hash, val, true
+    }
+  }
+  return Hash{}, nilVal, false
+}
+
+func (s *FileStore) FindBlock(hashPrefix string) (uint64, bool, error) {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  err := s.refresh()
+  if err != nil {
+    return 0, false, err
+  }
+  _, number, exist := findHash(s.hashes, hashPrefix)
+  return number, exist, nil
+}
+
+func (s *FileStore) FindTx(hashPrefix string) (Hash, TxPos, bool, error) {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  err := s.refresh()
+  if err != nil {
+    return Hash{}, TxPos{}, false, err
+  }
+  hash, pos, exist := findHash(s.txs, hashPrefix)
+  return hash, pos, exist, nil
+}
+
+func (s *FileStore) AccountTxs(acc Address) ([]TxPos, error) {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  err := s.refresh()
+  if err != nil {
+    return nil, err
+  }
+  return slices.Clone(s.accs[accHash(acc)]), nil
+}
+
+// truncateIndex truncates the index records ordered by the block number at the
+// first record after the block number
+func truncateIndex(path string, recLen, number int) error {
+  recs, err := os.ReadFile(path)
+  if err != nil {
+    return err
+  }
+  count := len(recs) / recLen
+  i := sort.Search(count, func(i int) bool {
+    rec := recs[i * recLen:(i + 1) * recLen]
+    return binary.BigEndian.Uint64(rec[32:40]) > uint64(number)
+  })
+  return os.Truncate(path, int64(i * recLen))
+}
+
+// Truncate truncates the block store first, so an interrupted truncation leaves
+// the indexes beyond the block store to be rebuilt on the next open of the
+// block store
+func (s *FileStore) Truncate(number uint64) error {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  height, err := s.Height()
+  if err != nil {
+    return err
+  }
+  if number >= height {
+    return nil
+  }
+  var size int64
+  if number > 0 {
+    offset, length, err := s.blockOffset(number)
+    if err != nil {
+      return err
+    }
+    size = offset + length
+  }
+  err = os.Truncate(s.path(blocksFile), size)
+  if err != nil {
+    return err
+  }
+  err = truncateIndex(s.path(txIdxFile), txIdxLen, int(number))
+  if err != nil {
+    return err
+  }
+  err = truncateIndex(s.path(accIdxFile), accIdxLen, int(number))
+  if err != nil {
+    return err
+  }
+  err = os.Truncate(s.path(hashIdxFile), int64(number) * hashIdxLen)
+  if err != nil {
+    return err
+  }
+  err = os.Truncate(s.path(blockIdxFile), int64(number) * blockIdxLen)
+  if err != nil {
+    return err
+  }
+  // Reload the in-memory indexes from the truncated index files
+  s.hashes, s.hashIdxOff = make(map[Hash]uint64), 0
+  s.txs, s.txIdxOff = make(map[Hash]TxPos), 0
+  s.accs, s.accIdxOff = make(map[Hash][]TxPos), 0
+  return s.refresh()
+}
@@ -0,0 +1,100 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestFileStore(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Open the file block store before any blocks are written
+  blockStore, err := chain.OpenFileStore(blockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create and persist several blocks directly to the block store directory
+  blks, err := createStoreBlocks(
+    gen, 3, func(blk chain.SigBlock) error {
+      return blk.Write(blockStoreDir)
+    },
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Run("indexes maintained by block write", func(t *testing.T) {
+    verifyBlockStore(t, blockStore, blks)
+  })
+  t.Run("indexes rebuilt on open", func(t *testing.T) {
+    // Remove the block store index to simulate a missing index
+    err := os.Remove(filepath.Join(blockStoreDir, "tx.idx"))
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Re-open the block store to rebuild the indexes from the block store
+    blockStore, err := chain.OpenFileStore(blockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    verifyBlockStore(t, blockStore, blks)
+  })
+  t.Run("truncate torn tail record", func(t *testing.T) {
+    // Append a partial block record to simulate a torn write
+    path := filepath.Join(blockStoreDir, "block.store")
+    file, err := os.OpenFile(path, os.O_APPEND | os.O_WRONLY, 0600)
+    if err != nil {
+      t.Fatal(err)
+    }
+    torn := []byte(`0badc0de {"number":4,"parent":`)
+    _, err = file.Write(torn)
+    if err != nil {
+      t.Fatal(err)
+    }
+    file.Close()
+    // Re-open the block store to truncate the torn tail record
+    blockStore, err := chain.OpenFileStore(blockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    verifyBlockStore(t, blockStore, blks)
+    // Verify that the recovery reports the height and the dropped bytes
+    rec := blockStore.Recovery()
+    if rec.Height != 3 || rec.DroppedBytes != int64(len(torn)) {
+      t.Errorf(
+        "invalid recovery: expected height 3, dropped %v, got %v",
+        len(torn), rec,
+      )
+    }
+    // Verify that a corrupted tail record is truncated as well
+    file, err = os.OpenFile(path, os.O_APPEND | os.O_WRONLY, 0600)
+    if err != nil {
+      t.Fatal(err)
+    }
+    corrupt := []byte("00000000 {\"number\":4}\n")
+    _, err = file.Write(corrupt)
+    if err != nil {
+      t.Fatal(err)
+    }
+    file.Close()
+    blockStore, err = chain.OpenFileStore(blockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    verifyBlockStore(t, blockStore, blks)
+    rec = blockStore.Recovery()
+    if rec.DroppedBytes != int64(len(corrupt)) {
+      t.Errorf(
+        "invalid dropped bytes: expected %v, got %v",
+        len(corrupt), rec.DroppedBytes,
+      )
+    }
+  })
+}
@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MaxReorgDepth is the maximum number of blocks reverted from the main chain
+// on the chain reorg. The candidate blocks deeper than the max reorg depth
+// below the main chain tip are pruned from the fork tree
+const MaxReorgDepth = 64
+
+// ForkTree keeps the candidate blocks of the competing branches that are not on
+// the main chain. The candidate blocks are linked to the parent blocks either
+// in the fork tree or on the main chain
+type ForkTree struct {
+  mtx sync.Mutex
+  blocks map[Hash]SigBlock
+}
+
+func NewForkTree() *ForkTree {
+  return &ForkTree{blocks: make(map[Hash]SigBlock)}
+}
+
+func (t *ForkTree) Add(blk SigBlock) {
+  t.mtx.Lock()
+  defer t.mtx.Unlock()
+  t.blocks[blk.Hash()] = blk
+}
+
+func (t *ForkTree) Contains(hash Hash) bool {
+  t.mtx.Lock()
+  defer t.mtx.Unlock()
+  _, exist := t.blocks[hash]
+  return exist
+}
+
+func (t *ForkTree) Remove(blks []SigBlock) {
+  t.mtx.Lock()
+  defer t.mtx.Unlock()
+  for _, blk := range blks {
+    delete(t.blocks, blk.Hash())
+  }
+}
+
+// Blocks returns the candidate blocks at the block number
+func (t *ForkTree) Blocks(number uint64) []SigBlock {
+  t.mtx.Lock()
+  defer t.mtx.Unlock()
+  var blks []SigBlock
+  for _, blk := range t.blocks {
+    if blk.Number == number {
+      blks = append(blks, blk)
+    }
+  }
+  return blks
+}
+
+// Prune removes the candidate blocks at or below the block number
+func (t *ForkTree) Prune(number uint64) {
+  t.mtx.Lock()
+  defer t.mtx.Unlock()
+  for hash, blk := range t.blocks {
+    if blk.Number <= number {
+      delete(t.blocks, hash)
+    }
+  }
+}
+
+// branch returns the branch of the candidate blocks ending with the block
+// ordered from the block right after the fork point
+func (t *ForkTree) branch(blk SigBlock) []SigBlock {
+  branch := []SigBlock{blk}
+  for {
+    parent, exist := t.blocks[branch[0].Parent]
+    if !exist {
+      return branch
+    }
+    branch = append([]SigBlock{parent}, branch...)
+  }
+}
+
+// BestBranch returns the branch with the most work among the branches that
+// contain the candidate block
+func (t *ForkTree) BestBranch(hash Hash) ([]SigBlock, error) {
+  t.mtx.Lock()
+  defer t.mtx.Unlock()
+  var best []SigBlock
+  for _, blk := range t.blocks {
+    branch := t.branch(blk)
+    if best != nil && ChainWork(blk) <= ChainWork(best[len(best) - 1]) {
+      continue
+    }
+    for _, b := range branch {
+      if b.Hash() == hash {
+        best = branch
+        break
+      }
+    }
+  }
+  if best == nil {
+    return nil, fmt.Errorf("blk error: candidate block %.7s not found", hash)
+  }
+  return best, nil
+}
@@ -12,22 +12,54 @@ import (
 const genesisFile = "genesis.json"
 
 type Genesis struct {
+  Version uint64 `json:"version,omitempty"`
   Chain string `json:"chain"`
   Authority Address `json:"authority"`
+  Validators []Address `json:"validators,omitempty"`
+  Consensus string `json:"consensus,omitempty"`
+  PoW *PoW `json:"pow,omitempty"`
+  Limits *BlockLimits `json:"limits,omitempty"`
   Balances map[Address]uint64 `json:"balances"`
   Time time.Time `json:"time"`
 }
 
-func NewGenesis(name string, authority, acc Address, balance uint64) Genesis {
+// NewGenesis creates the genesis signed by the authority. The authority is the
+// first validator in the ordered validator set followed by the validators
+func NewGenesis(
+  name string, authority, acc Address, balance uint64, validators ...Address,
+) Genesis {
   balances := make(map[Address]uint64, 1)
   balances[acc] = balance
   return Genesis{
-    Chain: name, Authority: authority, Balances: balances, Time: time.Now(),
+    Version: EncodingVersion, Chain: name, Authority: authority,
+    Validators: append([]Address{authority}, validators...),
+    Balances: balances, Time: time.Now(),
   }
 }
 
+// ValidatorSet returns the ordered validator set. The genesis without the
+// validator set is validated by the single authority
+func (g Genesis) ValidatorSet() []Address {
+  if len(g.Validators) == 0 {
+    return []Address{g.Authority}
+  }
+  return g.Validators
+}
+
+// ConsensusType returns the consensus of the blockchain. The genesis without
+// the consensus uses the proof of authority
+func (g Genesis) ConsensusType() string {
+  if len(g.Consensus) == 0 {
+    return ConsensusPoA
+  }
+  return g.Consensus
+}
+
 func (g Genesis) Hash() Hash {
-  return NewHash(g)
+  if g.Version == LegacyEncoding {
+    return NewHash(g)
+  }
+  return keccak(g.Encode())
 }
 
 type SigGenesis struct {
@@ -40,7 +72,10 @@ func NewSigGenesis(gen Genesis, sig []byte) SigGenesis {
 }
 
 func (g SigGenesis) Hash() Hash {
-  return NewHash(g)
+  if g.Version == LegacyEncoding {
+    return NewHash(g)
+  }
+  return keccak(g.Encode())
 }
 
 func (g SigGenesis) Write(dir string) error {
@@ -0,0 +1,225 @@
+package chain
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/kv"
+)
+
+const kvStoreFile = "block.kv"
+
+var kvHeightKey = []byte("height")
+
+func kvBlockKey(number uint64) []byte {
+  return []byte(fmt.Sprintf("blk/%016x", number))
+}
+
+func kvHashKey(hash string) []byte {
+  return []byte("hsh/" + hash)
+}
+
+func kvTxKey(hash string) []byte {
+  return []byte("tx/" + hash)
+}
+
+func kvAccPrefix(acc Address) string {
+  return fmt.Sprintf("acc/%v/", acc)
+}
+
+func kvAccKey(acc Address, pos []byte) []byte {
+  return []byte(fmt.Sprintf("%v%x", kvAccPrefix(acc), pos))
+}
+
+type KVStore struct {
+  db *kv.DB
+}
+
+func OpenKVStore(dir string) (*KVStore, error) {
+  err := os.MkdirAll(dir, 0700)
+  if err != nil {
+    return nil, err
+  }
+  db, err := kv.Open(filepath.Join(dir, kvStoreFile))
+  if err != nil {
+    return nil, err
+  }
+  return &KVStore{db: db}, nil
+}
+
+func (s *KVStore) WriteBlock(blk SigBlock) error {
+  jblk, err := json.Marshal(blk)
+  if err != nil {
+    return err
+  }
+  number := binary.BigEndian.AppendUint64(nil, blk.Number)
+  var batch kv.Batch
+  batch.Put(kvBlockKey(blk.Number), jblk)
+  batch.Put(kvHashKey(blk.Hash().String()), number)
+  for i, tx := range blk.Txs {
+    pos := binary.BigEndian.AppendUint64(number, uint64(i))
+    batch.Put(kvTxKey(tx.Hash().String()), pos)
+    batch.Put(kvAccKey(tx.From, pos), nil)
+    batch.Put(kvAccKey(tx.To, pos), nil)
+  }
+  batch.Put(kvHeightKey, number)
+  return s.db.Write(&batch)
+}
+
+func (s *KVStore) Height() (uint64, error) {
+  number, exist, err := s.db.Get(kvHeightKey)
+  if err != nil || !exist {
+    return 0, err
+  }
+  return binary.BigEndian.Uint64(number), nil
+}
+
+func (s *KVStore) BlockBytes(number uint64) ([]byte, error) {
+  jblk, exist, err := s.db.Get(kvBlockKey(number))
+  if err != nil {
+    return nil, err
+  }
+  if !exist {
+    return nil, fmt.Errorf("block %v not found", number)
+  }
+  return jblk, nil
+}
+
+func (s *KVStore) Block(number uint64) (SigBlock, error) {
+  jblk, err := s.BlockBytes(number)
+  if err != nil {
+    return SigBlock{}, err
+  }
+  var blk SigBlock
+  err = json.Unmarshal(jblk, &blk)
+  return blk, err
+}
+
+func (s *KVStore) BlocksBytes(from uint64) (
+  func(yield func(err error, jblk []byte) bool), func(), error,
+) {
+  if from == 0 {
+    from = 1
+  }
+  height, err := s.Height()
+  if err != nil {
+    return nil, nil, err
+  }
+  blocks := func(yield func(err error, jblk []byte) bool) {
+    for number := from; number <= height; number++ {
+      jblk, err := s.BlockBytes(number)
+      if err != nil {
+        yield(err, nil)
+        return
+      }
+      if !yield(nil, jblk) {
+        return
+      }
+    }
+  }
+  return blocks, func() {}, nil
+}
+
+func (s *KVStore) findKey(prefix, hashPrefix string) (
+  string, []byte, bool, error,
+) {
+  hashPrefix = strings.ToLower(hashPrefix)
+  if len(hashPrefix) == 0 {
+    return "", nil, false, nil
+  }
+  key := prefix + hashPrefix
+  if len(hashPrefix) != 2 * len(Hash{}) {
+    keys := s.db.Keys([]byte(key))
+    if len(keys) == 0 {
+      return "", nil, false, nil
+    }
+    key = keys[0]
+  }
+  val, exist, err := s.db.Get([]byte(key))
+  return strings.TrimPrefix(key, prefix), val, exist, err
+}
+
+func (s *KVStore) FindBlock(hashPrefix string) (uint64, bool, error) {
+  _, number, exist, err := s.findKey("hsh/", hashPrefix)
+  if err != nil || !exist {
+    return 0, false, err
+  }
+  return binary.BigEndian.Uint64(number), true, nil
+}
+
+func (s *KVStore) FindTx(hashPrefix string) (Hash, TxPos, bool, error) {
+  txh, pos, exist, err := s.findKey("tx/", hashPrefix)
+  if err != nil || !exist {
+    return Hash{}, TxPos{}, false, err
+  }
+  hash, err := DecodeHash(txh)
+  if err != nil {
+    return Hash{}, TxPos{}, false, err
+  }
+  txPos := TxPos{
+    Number: binary.BigEndian.Uint64(pos[0:8]),
+    Index: binary.BigEndian.Uint64(pos[8:16]),
+  }
+  return hash, txPos, true, nil
+}
+
+func (s *KVStore) AccountTxs(acc Address) ([]TxPos, error) {
+  prefix := kvAccPrefix(acc)
+  keys := s.db.Keys([]byte(prefix))
+  poss := make([]TxPos, len(keys))
+  for i, key := range keys {
+    pos, err := hex.DecodeString(strings.TrimPrefix(key, prefix))
+    if err != nil {
+      return nil, err
+    }
+    poss[i] = TxPos{
+      Number: binary.BigEndian.Uint64(pos[0:8]),
+      Index: binary.BigEndian.Uint64(pos[8:16]),
+    }
+  }
+  return poss, nil
+}
+
+// Truncate atomically deletes the blocks after the block number and the keys of
+// the deleted blocks
+func (s *KVStore) Truncate(number uint64) error {
+  height, err := s.Height()
+  if err != nil {
+    return err
+  }
+  if number >= height {
+    return nil
+  }
+  var batch kv.Batch
+  for n := number + 1; n <= height; n++ {
+    blk, err := s.Block(n)
+    if err != nil {
+      return err
+    }
+    nbytes := binary.BigEndian.AppendUint64(nil, n)
+    batch.Delete(kvBlockKey(n))
+    batch.Delete(kvHashKey(blk.Hash().String()))
+    for i, tx := range blk.Txs {
+      pos := binary.BigEndian.AppendUint64(nbytes, uint64(i))
+      batch.Delete(kvTxKey(tx.Hash().String()))
+      batch.Delete(kvAccKey(tx.From, pos))
+      batch.Delete(kvAccKey(tx.To, pos))
+    }
+  }
+  batch.Put(kvHeightKey, binary.BigEndian.AppendUint64(nil, number))
+  return s.db.Write(&batch)
+}
+
+func (s *KVStore) Recovery() Recovery {
+  height, _ := s.Height()
+  return Recovery{Height: height, DroppedBytes: s.db.Dropped()}
+}
+
+func (s *KVStore) Close() error {
+  return s.db.Close()
+}
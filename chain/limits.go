@@ -0,0 +1,42 @@
+package chain
+
+import "fmt"
+
+// BlockLimits are the consensus parameters that bound the size of the block.
+// The zero limit is not enforced
+type BlockLimits struct {
+  MaxTxs uint64 `json:"maxTxs,omitempty"`
+  MaxBytes uint64 `json:"maxBytes,omitempty"`
+}
+
+// BlockSize returns the size of the canonical encoding of the signed block
+// with the txs and the evidence blocks in bytes. The commit is excluded as the
+// block size is fixed before the block is committed
+func BlockSize(blk SigBlock) uint64 {
+  size := uint64(len(blk.Encode()))
+  for _, tx := range blk.Txs {
+    size += TxSize(tx)
+  }
+  for _, ev := range blk.Evidence {
+    size += uint64(len(ev.Block1.Encode()) + len(ev.Block2.Encode()))
+  }
+  return size
+}
+
+// blockOverhead returns the size of the encoded block without the txs. The
+// header fields are of the fixed size, and the signature takes the full length
+func blockOverhead(evs []Evidence) uint64 {
+  blk := SigBlock{Block: Block{Evidence: evs}, Sig: make([]byte, 65)}
+  return BlockSize(blk)
+}
+
+// verifyLimits verifies that the block does not exceed the block limits
+func (s *State) verifyLimits(blk SigBlock) error {
+  if s.limits.MaxTxs > 0 && uint64(len(blk.Txs)) > s.limits.MaxTxs {
+    return fmt.Errorf("blk error: too many transactions\n%v", blk)
+  }
+  if s.limits.MaxBytes > 0 && BlockSize(blk) > s.limits.MaxBytes {
+    return fmt.Errorf("blk error: block too large\n%v", blk)
+  }
+  return nil
+}
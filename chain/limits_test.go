@@ -0,0 +1,111 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestBlockLimits(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state with the block limits and apply several pending txs
+  newState := func(limits chain.BlockLimits) *chain.State {
+    gen := gen
+    gen.Limits = &limits
+    state := chain.NewState(gen)
+    for _, value := range []uint64{12, 34, 56} {
+      tx := chain.NewTx(
+        chainName, acc.Address(), chain.Address("to"), value,
+        state.Pending.Nonce(acc.Address()) + 1,
+      )
+      stx, err := acc.SignTx(tx)
+      if err != nil {
+        t.Fatal(err)
+      }
+      err = state.Pending.ApplyTx(stx)
+      if err != nil {
+        t.Fatal(err)
+      }
+    }
+    return state
+  }
+  createBlock := func(state *chain.State) chain.SigBlock {
+    clone := state.Clone()
+    blk, err := clone.CreateBlock(auth, 0)
+    if err != nil {
+      t.Fatal(err)
+    }
+    return blk
+  }
+  t.Run("max txs per block", func(t *testing.T) {
+    // Verify that the block includes only the max number of txs
+    state := newState(chain.BlockLimits{MaxTxs: 2})
+    blk := createBlock(state)
+    if len(blk.Txs) != 2 {
+      t.Fatalf("invalid number of txs: expected %v, got %v", 2, len(blk.Txs))
+    }
+    err := state.ApplyBlockToState(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the leftover tx is included in the next block
+    blk = createBlock(state)
+    if len(blk.Txs) != 1 || blk.Txs[0].Value != 56 {
+      t.Errorf("invalid next block: expected leftover tx")
+    }
+    // Verify that the block with too many txs is rejected
+    blk = createBlock(newState(chain.BlockLimits{}))
+    clone := newState(chain.BlockLimits{MaxTxs: 2}).Clone()
+    err = clone.ApplyBlock(blk)
+    if err == nil {
+      t.Errorf("block with too many txs applied")
+    }
+  })
+  t.Run("max block bytes", func(t *testing.T) {
+    // Verify that the block larger than the max block size is rejected
+    blk := createBlock(newState(chain.BlockLimits{}))
+    maxBytes := chain.BlockSize(blk) - 1
+    clone := newState(chain.BlockLimits{MaxBytes: maxBytes}).Clone()
+    err := clone.ApplyBlock(blk)
+    if err == nil {
+      t.Errorf("block larger than max block size applied")
+    }
+    // Verify that the created block fits the max block size and leaves the
+    // rest of the txs pending
+    state := newState(chain.BlockLimits{MaxBytes: maxBytes})
+    blk = createBlock(state)
+    if len(blk.Txs) == 0 || len(blk.Txs) == 3 {
+      t.Errorf("invalid number of txs: expected 1..2, got %v", len(blk.Txs))
+    }
+    if chain.BlockSize(blk) > maxBytes {
+      t.Errorf(
+        "invalid block size: expected at most %v, got %v",
+        maxBytes, chain.BlockSize(blk),
+      )
+    }
+    err = state.ApplyBlockToState(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+  })
+}
@@ -0,0 +1,121 @@
+package chain
+
+import (
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/dustinxie/ecc"
+)
+
+// PoW defines the initial difficulty in leading zero bits of the block hash and
+// the target block time of the proof of work consensus
+type PoW struct {
+  Difficulty uint64 `json:"difficulty"`
+  BlockTime time.Duration `json:"blockTime"`
+}
+
+const (
+  maxDifficulty = 63
+  mineStopCheck = 4096
+)
+
+func leadingZeros(hash Hash) uint64 {
+  var zeros int
+  for _, b := range hash {
+    zeros += bits.LeadingZeros8(b)
+    if b != 0 {
+      break
+    }
+  }
+  return uint64(zeros)
+}
+
+// VerifyBlockWork verifies that the block hash has at least the block
+// difficulty leading zero bits
+func VerifyBlockWork(blk SigBlock) bool {
+  return blk.Difficulty > 0 && leadingZeros(blk.Block.Hash()) >= blk.Difficulty
+}
+
+// VerifyNoWork verifies that the block outside the proof of work consensus
+// does not claim the proof of work, so the block counts as a unit of work
+func VerifyNoWork(blk SigBlock) error {
+  if blk.Nonce != 0 || blk.Difficulty != 0 || blk.TotalWork != 0 {
+    return fmt.Errorf("blk error: unexpected proof of work\n%v", blk)
+  }
+  return nil
+}
+
+// ChainWork returns the total work of the chain ending with the block. Every
+// block without the proof of work counts as a unit of work, so the chain with
+// the most work is the longest chain
+func ChainWork(blk SigBlock) uint64 {
+  if blk.TotalWork == 0 {
+    return blk.Number
+  }
+  return blk.TotalWork
+}
+
+// Retarget adjusts the difficulty by one bit toward the target block time
+func Retarget(difficulty uint64, elapsed, blockTime time.Duration) uint64 {
+  switch {
+  case elapsed < blockTime / 2 && difficulty < maxDifficulty:
+    return difficulty + 1
+  case elapsed > blockTime * 2 && difficulty > 1:
+    return difficulty - 1
+  default:
+    return difficulty
+  }
+}
+
+// nextWork returns the difficulty and the total work of the next block from the
+// difficulty and the time of the parent block
+func (s *State) nextWork(blkTime time.Time) (uint64, uint64) {
+  difficulty := s.pow.Difficulty
+  var totalWork uint64
+  if s.lastBlock.Number > 0 {
+    difficulty, totalWork = s.lastBlock.Difficulty, s.lastBlock.TotalWork
+  }
+  elapsed := blkTime.Sub(s.parentTime())
+  difficulty = Retarget(difficulty, elapsed, s.pow.BlockTime)
+  return difficulty, totalWork + 1 << difficulty
+}
+
+// MineBlock searches the nonce of the new block that satisfies the difficulty.
+// The mining is abandoned as soon as the stop function returns true
+func (s *State) MineBlock(miner Account, stop func() bool) (SigBlock, error) {
+  // The is no need to lock/unlock as the MineBlock is always executed on the
+  // cloned state
+  s.proposer = miner.Address()
+  blk, err := s.newBlock()
+  if err != nil {
+    return SigBlock{}, err
+  }
+  blk.Difficulty, blk.TotalWork = s.nextWork(blk.Time)
+  for nonce := uint64(0); ; nonce++ {
+    if nonce % mineStopCheck == 0 && stop() {
+      return SigBlock{}, fmt.Errorf("mining stopped")
+    }
+    blk.Nonce = nonce
+    if leadingZeros(blk.Hash()) >= blk.Difficulty {
+      return miner.SignBlock(blk)
+    }
+  }
+}
+
+// verifyWork verifies the proof of work and the retargeted difficulty of the
+// block
+func (s *State) verifyWork(blk SigBlock) error {
+  _, err := ecc.RecoverPubkey("P-256k1", blk.Block.Hash().Bytes(), blk.Sig)
+  if err != nil {
+    return err
+  }
+  if !VerifyBlockWork(blk) {
+    return fmt.Errorf("blk error: invalid proof of work\n%v", blk)
+  }
+  difficulty, totalWork := s.nextWork(blk.Time)
+  if blk.Difficulty != difficulty || blk.TotalWork != totalWork {
+    return fmt.Errorf("blk error: invalid difficulty\n%v", blk)
+  }
+  return nil
+}
@@ -0,0 +1,180 @@
+package chain_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestMineBlock(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  // Create the miner account and the initial owner account
+  miner, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  acc, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the proof of work genesis with the low difficulty
+  gen := chain.NewGenesis(
+    chainName, miner.Address(), acc.Address(), ownerBalance,
+  )
+  gen.Consensus = chain.ConsensusPoW
+  gen.PoW = &chain.PoW{Difficulty: 4, BlockTime: 10 * time.Second}
+  sgen, err := miner.SignGen(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create and apply a transaction to the pending state
+  state := chain.NewState(sgen)
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Mine the block on the cloned state
+  clone := state.Clone()
+  blk, err := clone.MineBlock(miner, func() bool { return false })
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Run("mined block is applied", func(t *testing.T) {
+    // Verify that the block hash satisfies the retargeted difficulty
+    if !chain.VerifyBlockWork(blk) {
+      t.Errorf("invalid proof of work")
+    }
+    if blk.Difficulty != 5 {
+      t.Errorf("invalid difficulty: expected %v, got %v", 5, blk.Difficulty)
+    }
+    if chain.ChainWork(blk) != 1 << 5 {
+      t.Errorf(
+        "invalid chain work: expected %v, got %v",
+        1 << 5, chain.ChainWork(blk),
+      )
+    }
+    // Verify that the mined block is applied to the state
+    clone := state.Clone()
+    err := clone.ApplyBlock(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+  })
+  t.Run("tampered block is rejected", func(t *testing.T) {
+    // Verify that the block with the invalid nonce is rejected
+    tamper := blk
+    tamper.Nonce++
+    for chain.VerifyBlockWork(tamper) {
+      tamper.Nonce++
+    }
+    clone := state.Clone()
+    err := clone.ApplyBlock(tamper)
+    if err == nil {
+      t.Errorf("block with invalid nonce applied")
+    }
+    // Verify that the block with the lowered difficulty is rejected
+    tamper = blk
+    tamper.Difficulty = 1
+    clone = state.Clone()
+    err = clone.ApplyBlock(tamper)
+    if err == nil {
+      t.Errorf("block with lowered difficulty applied")
+    }
+  })
+  t.Run("mining is stopped", func(t *testing.T) {
+    // Verify that the mining is abandoned when the stop function returns true
+    clone := state.Clone()
+    _, err := clone.MineBlock(miner, func() bool { return true })
+    if err == nil {
+      t.Errorf("mining is not stopped")
+    }
+  })
+}
+
+func TestRetarget(t *testing.T) {
+  blockTime := 10 * time.Second
+  cases := []struct{
+    difficulty uint64
+    elapsed time.Duration
+    exp uint64
+  }{
+    {8, 2 * time.Second, 9}, {8, 10 * time.Second, 8},
+    {8, 30 * time.Second, 7}, {1, 30 * time.Second, 1},
+    {63, 2 * time.Second, 63},
+  }
+  for _, c := range cases {
+    // Verify that the difficulty moves by one bit toward the block time
+    got := chain.Retarget(c.difficulty, c.elapsed, blockTime)
+    if got != c.exp {
+      t.Errorf(
+        "invalid difficulty for %v: expected %v, got %v",
+        c.elapsed, c.exp, got,
+      )
+    }
+  }
+}
+
+func TestNoWorkOutsidePoW(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the proof of authority genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(gen)
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create and apply a transaction to the pending state
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the block and re-sign the block with the forged total work
+  blk, err := state.Clone().CreateBlock(auth, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  forged := blk.Block
+  forged.TotalWork = math.MaxUint64
+  sforged, err := auth.SignBlock(forged)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the block claiming the proof of work is rejected
+  clone := state.Clone()
+  err = clone.ApplyBlock(sforged)
+  if err == nil {
+    t.Errorf("block with forged total work applied")
+  }
+  // Verify that the honest block is applied
+  clone = state.Clone()
+  err = clone.ApplyBlock(blk)
+  if err != nil {
+    t.Fatal(err)
+  }
+}
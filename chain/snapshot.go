@@ -0,0 +1,215 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+  snapshotDir = "snapshot"
+  // The number of the latest snapshots kept in the snapshot directory
+  snapshotKeep = 2
+)
+
+type Snapshot struct {
+  GenesisHash Hash `json:"genesisHash"`
+  Balances map[Address]uint64 `json:"balances"`
+  Nonces map[Address]uint64 `json:"nonces"`
+  LastBlock SigBlock `json:"lastBlock"`
+  Validators []Validator `json:"validators,omitempty"`
+  Jailed map[Address]uint64 `json:"jailed,omitempty"`
+}
+
+func (s Snapshot) Hash() Hash {
+  return NewHash(s)
+}
+
+// SealSnapshot seals the snapshot with the snapshot hash to detect a
+// corrupted or tampered snapshot before loading the snapshot
+type SealSnapshot struct {
+  Snapshot
+  Seal Hash `json:"seal"`
+}
+
+func NewSealSnapshot(snap Snapshot) SealSnapshot {
+  return SealSnapshot{Snapshot: snap, Seal: snap.Hash()}
+}
+
+func (s SealSnapshot) String() string {
+  return fmt.Sprintf(
+    "snp %7d: %.7s   gen %.7s",
+    s.LastBlock.Number, s.Seal, s.GenesisHash,
+  )
+}
+
+func snapshotFile(number uint64) string {
+  return fmt.Sprintf("%016d.json", number)
+}
+
+// Write atomically persists the snapshot and prunes the older snapshots
+func (s SealSnapshot) Write(dir string) error {
+  jsnap, err := json.Marshal(s)
+  if err != nil {
+    return err
+  }
+  dir = filepath.Join(dir, snapshotDir)
+  err = os.MkdirAll(dir, 0700)
+  if err != nil {
+    return err
+  }
+  path := filepath.Join(dir, snapshotFile(s.LastBlock.Number))
+  tmp := path + ".tmp"
+  err = os.WriteFile(tmp, jsnap, 0600)
+  if err != nil {
+    return err
+  }
+  err = os.Rename(tmp, path)
+  if err != nil {
+    return err
+  }
+  numbers, err := snapshotNumbers(dir)
+  if err != nil {
+    return err
+  }
+  for len(numbers) > snapshotKeep {
+    path := filepath.Join(dir, snapshotFile(numbers[len(numbers) - 1]))
+    err := os.Remove(path)
+    if err != nil {
+      return err
+    }
+    numbers = numbers[:len(numbers) - 1]
+  }
+  return nil
+}
+
+// snapshotNumbers returns the block numbers of the persisted snapshots from
+// the latest to the earliest
+func snapshotNumbers(dir string) ([]uint64, error) {
+  entries, err := os.ReadDir(dir)
+  if os.IsNotExist(err) {
+    return nil, nil
+  }
+  if err != nil {
+    return nil, err
+  }
+  numbers := make([]uint64, 0, len(entries))
+  for _, entry := range entries {
+    name, found := strings.CutSuffix(entry.Name(), ".json")
+    if !found {
+      continue
+    }
+    var number uint64
+    _, err := fmt.Sscanf(name, "%d", &number)
+    if err != nil {
+      continue
+    }
+    numbers = append(numbers, number)
+  }
+  slices.Sort(numbers)
+  slices.Reverse(numbers)
+  return numbers, nil
+}
+
+func ReadSnapshot(dir string, number uint64) (SealSnapshot, error) {
+  path := filepath.Join(dir, snapshotDir, snapshotFile(number))
+  jsnap, err := os.ReadFile(path)
+  if err != nil {
+    return SealSnapshot{}, err
+  }
+  var snap SealSnapshot
+  err = json.Unmarshal(jsnap, &snap)
+  if err != nil {
+    return SealSnapshot{}, err
+  }
+  if snap.Snapshot.Hash() != snap.Seal {
+    return SealSnapshot{}, fmt.Errorf("snapshot %v: invalid seal", number)
+  }
+  return snap, nil
+}
+
+// ReadSnapshotBytes reads the persisted snapshot at the block number or the
+// latest persisted snapshot if the block number is 0
+func ReadSnapshotBytes(dir string, number uint64) ([]byte, error) {
+  if number == 0 {
+    numbers, err := snapshotNumbers(filepath.Join(dir, snapshotDir))
+    if err != nil {
+      return nil, err
+    }
+    if len(numbers) == 0 {
+      return nil, fmt.Errorf("snapshot not found")
+    }
+    number = numbers[0]
+  }
+  path := filepath.Join(dir, snapshotDir, snapshotFile(number))
+  return os.ReadFile(path)
+}
+
+// ReadSnapshots reads the persisted snapshots from the latest to the earliest.
+// A corrupted snapshot is yielded with an error
+func ReadSnapshots(dir string) (
+  func(yield func(err error, snap SealSnapshot) bool), error,
+) {
+  numbers, err := snapshotNumbers(filepath.Join(dir, snapshotDir))
+  if err != nil {
+    return nil, err
+  }
+  snaps := func(yield func(err error, snap SealSnapshot) bool) {
+    for _, number := range numbers {
+      snap, err := ReadSnapshot(dir, number)
+      if !yield(err, snap) {
+        return
+      }
+    }
+  }
+  return snaps, nil
+}
+
+func (s *State) Snapshot() SealSnapshot {
+  s.mtx.RLock()
+  defer s.mtx.RUnlock()
+  snap := Snapshot{
+    GenesisHash: s.genesisHash,
+    Balances: maps.Clone(s.balances), Nonces: maps.Clone(s.nonces),
+    LastBlock: s.lastBlock, Validators: slices.Clone(s.validators),
+    Jailed: maps.Clone(s.jailed),
+  }
+  return NewSealSnapshot(snap)
+}
+
+// NewStateFromSnapshot restores the confirmed and the pending state from the
+// snapshot of the chain with the genesis. The snapshot must match the state
+// root committed in the last block of the snapshot
+func NewStateFromSnapshot(gen SigGenesis, snap SealSnapshot) (*State, error) {
+  if snap.Snapshot.Hash() != snap.Seal {
+    return nil, fmt.Errorf("snapshot error: invalid seal\n%v", snap)
+  }
+  if snap.GenesisHash != gen.Hash() {
+    return nil, fmt.Errorf("snapshot error: invalid genesis hash\n%v", snap)
+  }
+  stateRoot, err := StateRoot(snap.Balances, snap.Nonces)
+  if err != nil {
+    return nil, err
+  }
+  if stateRoot != snap.LastBlock.StateRoot {
+    return nil, fmt.Errorf("snapshot error: invalid state root\n%v", snap)
+  }
+  state := NewState(gen)
+  for _, st := range []*State{state, state.Pending} {
+    st.balances = make(map[Address]uint64, len(snap.Balances))
+    maps.Copy(st.balances, snap.Balances)
+    st.nonces = make(map[Address]uint64, len(snap.Nonces))
+    maps.Copy(st.nonces, snap.Nonces)
+    if len(snap.Validators) > 0 {
+      st.validators = slices.Clone(snap.Validators)
+    }
+    st.jailed = make(map[Address]uint64, len(snap.Jailed))
+    maps.Copy(st.jailed, snap.Jailed)
+  }
+  state.lastBlock = snap.LastBlock
+  return state, nil
+}
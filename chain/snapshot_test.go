@@ -0,0 +1,102 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestSnapshotWriteReadRestore(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create several blocks and persist the state snapshot after every block
+  state := chain.NewState(gen)
+  _, err = createStoreBlocks(
+    gen, 3, func(blk chain.SigBlock) error {
+      err := state.ApplyBlockToState(blk)
+      if err != nil {
+        return err
+      }
+      return state.Snapshot().Write(blockStoreDir)
+    },
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  t.Run("latest snapshots kept", func(t *testing.T) {
+    snaps, err := chain.ReadSnapshots(blockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that only the latest snapshots are kept from the latest to the
+    // earliest
+    numbers := make([]uint64, 0)
+    for err, snap := range snaps {
+      if err != nil {
+        t.Fatal(err)
+      }
+      numbers = append(numbers, snap.LastBlock.Number)
+    }
+    if len(numbers) != 2 || numbers[0] != 3 || numbers[1] != 2 {
+      t.Errorf("invalid snapshots: expected [3 2], got %v", numbers)
+    }
+  })
+  t.Run("restore state from snapshot", func(t *testing.T) {
+    snap, err := chain.ReadSnapshot(blockStoreDir, 3)
+    if err != nil {
+      t.Fatal(err)
+    }
+    restored, err := chain.NewStateFromSnapshot(gen, snap)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the restored state is equal to the snapshotted state
+    if restored.LastBlock().Hash() != state.LastBlock().Hash() {
+      t.Errorf("invalid last block")
+    }
+    expBalance, _ := state.Balance(ownerAcc)
+    gotBalance, _ := restored.Balance(ownerAcc)
+    if gotBalance != expBalance {
+      t.Errorf("invalid balance: expected %v, got %v", expBalance, gotBalance)
+    }
+    expNonce, gotNonce := state.Nonce(ownerAcc), restored.Nonce(ownerAcc)
+    if gotNonce != expNonce {
+      t.Errorf("invalid nonce: expected %v, got %v", expNonce, gotNonce)
+    }
+    // Verify that the pending state is restored from the snapshot
+    gotNonce = restored.Pending.Nonce(ownerAcc)
+    if gotNonce != expNonce {
+      t.Errorf("invalid pending nonce: expected %v, got %v", expNonce, gotNonce)
+    }
+  })
+  t.Run("reject tampered snapshot", func(t *testing.T) {
+    // Tamper the snapshot balance without updating the snapshot seal
+    snap, err := chain.ReadSnapshot(blockStoreDir, 3)
+    if err != nil {
+      t.Fatal(err)
+    }
+    snap.Balances[ownerAcc] += 1000
+    _, err = chain.NewStateFromSnapshot(gen, snap)
+    if err == nil {
+      t.Errorf("tampered snapshot restored")
+    }
+    // Corrupt the persisted snapshot
+    path := filepath.Join(blockStoreDir, "snapshot", "0000000000000003.json")
+    err = os.WriteFile(path, []byte(`{"seal":"00"}`), 0600)
+    if err != nil {
+      t.Fatal(err)
+    }
+    _, err = chain.ReadSnapshot(blockStoreDir, 3)
+    if err == nil {
+      t.Errorf("corrupted snapshot read")
+    }
+  })
+}
@@ -1,37 +1,72 @@
 package chain
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
 type State struct {
   mtx sync.RWMutex
-  authority Address
+  version uint64
+  chain string
+  validators []Validator
+  consensus string
+  pow PoW
+  limits BlockLimits
+  genesisTime time.Time
   balances map[Address]uint64
   nonces map[Address]uint64
+  jailed map[Address]uint64
+  // The accounts changed by the last applied block
+  touched map[Address]bool
   lastBlock SigBlock
+  blockTime time.Time
+  proposer Address
   genesisHash Hash
   txs map[Hash]SigTx
+  evidence map[Hash]Evidence
   Pending *State
 }
 
 func NewState(gen SigGenesis) *State {
+  var pow PoW
+  if gen.PoW != nil {
+    pow = *gen.PoW
+  }
+  var limits BlockLimits
+  if gen.Limits != nil {
+    limits = *gen.Limits
+  }
   return &State{
-    authority: gen.Authority,
+    version: gen.Version,
+    chain: gen.Chain,
+    validators: NewValidators(gen.ValidatorSet()),
+    consensus: gen.ConsensusType(),
+    pow: pow,
+    limits: limits,
+    genesisTime: gen.Time,
     balances: maps.Clone(gen.Balances),
     nonces: make(map[Address]uint64),
+    jailed: make(map[Address]uint64),
     genesisHash: gen.Hash(),
     txs: make(map[Hash]SigTx),
     Pending: &State{
-      authority: gen.Authority,
+      version: gen.Version,
+      chain: gen.Chain,
+      validators: NewValidators(gen.ValidatorSet()),
+      consensus: gen.ConsensusType(),
       balances: maps.Clone(gen.Balances),
       nonces: make(map[Address]uint64),
+      jailed: make(map[Address]uint64),
       genesisHash: gen.Hash(),
       txs: make(map[Hash]SigTx),
+      evidence: make(map[Hash]Evidence),
     },
   }
 }
@@ -40,33 +75,162 @@ func (s *State) Clone() *State {
   s.mtx.RLock()
   defer s.mtx.RUnlock()
   return &State{
-    authority: s.authority,
+    version: s.version,
+    chain: s.chain,
+    validators: slices.Clone(s.validators),
+    consensus: s.consensus,
+    pow: s.pow,
+    limits: s.limits,
+    genesisTime: s.genesisTime,
     balances: maps.Clone(s.balances),
     nonces: maps.Clone(s.nonces),
+    jailed: maps.Clone(s.jailed),
+    touched: maps.Clone(s.touched),
     lastBlock: s.lastBlock,
     genesisHash: s.genesisHash,
     txs: maps.Clone(s.txs),
     Pending: &State{
       txs: maps.Clone(s.Pending.txs),
+      evidence: maps.Clone(s.Pending.evidence),
     },
   }
 }
 
+// Apply replaces the state with the cloned state after the block application
+// and re-applies the pending txs in one step
 func (s *State) Apply(clone *State) {
   s.mtx.Lock()
   defer s.mtx.Unlock()
   s.balances = clone.balances
   s.nonces = clone.nonces
+  s.jailed = clone.jailed
+  s.touched = clone.touched
   s.lastBlock = clone.lastBlock
-  s.Pending.balances = maps.Clone(s.balances)
-  s.Pending.nonces = maps.Clone(s.nonces)
+  s.validators = clone.validators
+  s.Pending.mtx.Lock()
+  defer s.Pending.mtx.Unlock()
   for _, tx := range clone.lastBlock.Txs {
     delete(s.Pending.txs, tx.Hash())
   }
+  s.reapplyPending(nonceOrder(s.Pending.txs))
+}
+
+// resetPending resets the pending state to the confirmed state. The pending
+// evidence against the already jailed validators is removed
+func (s *State) resetPending() {
+  s.Pending.lastBlock = s.lastBlock
+  s.Pending.balances = maps.Clone(s.balances)
+  s.Pending.nonces = maps.Clone(s.nonces)
+  s.Pending.jailed = maps.Clone(s.jailed)
+  s.Pending.validators = slices.Clone(s.validators)
+  for hash, ev := range s.Pending.evidence {
+    offender, err := VerifyEvidence(ev)
+    _, jailed := s.jailed[offender]
+    if err != nil || jailed {
+      delete(s.Pending.evidence, hash)
+    }
+  }
 }
 
-func (s *State) Authority() Address {
-  return s.authority
+// ResetPending resets the pending state to the confirmed state and re-applies
+// the pending txs in order. The txs that are no longer valid on the confirmed
+// state are dropped and returned
+func (s *State) ResetPending(txs []SigTx) []SigTx {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  s.Pending.mtx.Lock()
+  defer s.Pending.mtx.Unlock()
+  return s.reapplyPending(txs)
+}
+
+// reapplyPending resets the pending state and re-applies the pending txs under
+// the lock of the pending state, so the readers of the pending state never see
+// the pending state without the pending txs
+func (s *State) reapplyPending(txs []SigTx) []SigTx {
+  s.resetPending()
+  s.Pending.txs = make(map[Hash]SigTx, len(txs))
+  var rejected []SigTx
+  for _, tx := range txs {
+    err := s.Pending.applyTx(tx)
+    if err != nil {
+      rejected = append(rejected, tx)
+    }
+  }
+  return rejected
+}
+
+// nonceOrder orders the pending txs by the account and the nonce for the
+// re-application of the pending txs
+func nonceOrder(txs map[Hash]SigTx) []SigTx {
+  return slices.SortedFunc(maps.Values(txs), func(a, b SigTx) int {
+    return cmp.Or(
+      strings.Compare(string(a.From), string(b.From)),
+      cmp.Compare(a.Nonce, b.Nonce),
+    )
+  })
+}
+
+// SetPendingTxs replaces the pending txs of the cloned state with the ready
+// txs of the mempool to create the next block from
+func (s *State) SetPendingTxs(txs []SigTx) {
+  s.Pending.txs = make(map[Hash]SigTx, len(txs))
+  for _, tx := range txs {
+    s.Pending.txs[tx.Hash()] = tx
+  }
+}
+
+// Reorg replaces the state with the state of the new main chain after the chain
+// reorg. The txs of the reverted blocks return to the pending txs, and the
+// pending txs already confirmed on the new main chain are removed
+func (s *State) Reorg(state *State, reverted []SigBlock) {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  s.balances = state.balances
+  s.nonces = state.nonces
+  s.jailed = state.jailed
+  s.touched = state.touched
+  s.lastBlock = state.lastBlock
+  s.validators = state.validators
+  s.Pending.mtx.Lock()
+  defer s.Pending.mtx.Unlock()
+  for _, blk := range reverted {
+    for _, tx := range blk.Txs {
+      s.Pending.txs[tx.Hash()] = tx
+    }
+    for _, ev := range blk.Evidence {
+      s.Pending.evidence[ev.Hash()] = ev
+    }
+  }
+  for hash, tx := range s.Pending.txs {
+    if tx.Nonce <= s.nonces[tx.From] {
+      delete(s.Pending.txs, hash)
+    }
+  }
+  s.reapplyPending(nonceOrder(s.Pending.txs))
+}
+
+// Validators returns the addresses of the ordered validator set
+func (s *State) Validators() []Address {
+  s.mtx.RLock()
+  defer s.mtx.RUnlock()
+  return validatorAddrs(s.validators)
+}
+
+// ValidatorSet returns the ordered validator set with the voting power of
+// every validator
+func (s *State) ValidatorSet() []Validator {
+  s.mtx.RLock()
+  defer s.mtx.RUnlock()
+  return slices.Clone(s.validators)
+}
+
+// Chain returns the chain name committed into every tx of the chain
+func (s *State) Chain() string {
+  return s.chain
+}
+
+func (s *State) Consensus() string {
+  return s.consensus
 }
 
 func (s *State) Balance(acc Address) (uint64, bool) {
@@ -82,6 +246,10 @@ func (s *State) Nonce(acc Address) uint64 {
   return s.nonces[acc]
 }
 
+func (s *State) GenesisHash() Hash {
+  return s.genesisHash
+}
+
 func (s *State) LastBlock() SigBlock {
   s.mtx.RLock()
   defer s.mtx.RUnlock()
@@ -119,6 +287,10 @@ func (s *State) String() string {
 func (s *State) ApplyTx(tx SigTx) error {
   s.mtx.Lock()
   defer s.mtx.Unlock()
+  return s.applyTx(tx)
+}
+
+func (s *State) applyTx(tx SigTx) error {
   valid, err := VerifyTx(tx)
   if err != nil {
     return err
@@ -126,46 +298,173 @@ func (s *State) ApplyTx(tx SigTx) error {
   if !valid {
     return fmt.Errorf("tx error: invalid transaction signature\n%v\n", tx)
   }
+  legacy := s.version == LegacyEncoding && tx.Version == LegacyEncoding &&
+    len(tx.Chain) == 0
+  if tx.Chain != s.chain && !legacy {
+    return fmt.Errorf("tx error: invalid transaction chain\n%v\n", tx)
+  }
+  err = s.verifyVersion(tx.Version)
+  if err != nil {
+    return fmt.Errorf("tx error: %v\n%v\n", err, tx)
+  }
   if tx.Nonce != s.nonces[tx.From] + 1 {
     return fmt.Errorf("tx error: invalid transaction nonce\n%v\n", tx)
   }
-  if s.balances[tx.From] < tx.Value {
+  // The pending state without the block time verifies the expiry at the
+  // current time
+  blockTime := s.blockTime
+  if blockTime.IsZero() {
+    blockTime = time.Now()
+  }
+  if tx.Expired(s.lastBlock.Number + 1, blockTime) {
+    return fmt.Errorf("tx error: expired transaction\n%v\n", tx)
+  }
+  if tx.Value + tx.Fee < tx.Value ||
+    s.balances[tx.From] < tx.Value + tx.Fee {
     return fmt.Errorf("tx error: insufficient account funds\n%v\n", tx)
   }
+  if tx.Kind != TxTransfer {
+    err := s.applyValidatorTx(tx)
+    if err != nil {
+      return err
+    }
+    s.chargeFee(tx)
+    s.nonces[tx.From]++
+    s.txs[tx.Hash()] = tx
+    s.touch(tx.From)
+    return nil
+  }
   s.balances[tx.From] -= tx.Value
   s.balances[tx.To] += tx.Value
+  s.touch(tx.From, tx.To)
+  s.chargeFee(tx)
   s.nonces[tx.From]++
   s.txs[tx.Hash()] = tx
   return nil
 }
 
-func (s *State) CreateBlock(authority Account) (SigBlock, error) {
-  // The is no need to lock/unlock as the CreateBlock is always executed on the
-  // cloned state
-  pndTxs := make([]SigTx, 0, len(s.Pending.txs))
-  for _, tx := range s.Pending.txs {
-    pndTxs = append(pndTxs, tx)
+// chargeFee deducts the tx fee from the sender and credits the tx fee to the
+// block proposer. The pending state without the proposer only deducts the fee
+func (s *State) chargeFee(tx SigTx) {
+  if tx.Fee == 0 {
+    return
   }
-  slices.SortFunc(pndTxs, func(a, b SigTx) int {
-    if a.Time.Before(b.Time) {
-      return -1
-    }
-    if b.Time.Before(a.Time) {
-      return 1
-    }
+  s.balances[tx.From] -= tx.Fee
+  if s.proposer != "" {
+    s.balances[s.proposer] += tx.Fee
+    s.touch(s.proposer)
+  }
+}
+
+// touch records the accounts changed by the applied block. The pending state
+// does not record the changed accounts
+func (s *State) touch(accs ...Address) {
+  if s.touched == nil {
+    return
+  }
+  for _, acc := range accs {
+    s.touched[acc] = true
+  }
+}
+
+const (
+  // maxFutureBlockTime bounds the clock drift of the proposer
+  maxFutureBlockTime = 2 * time.Minute
+  // SlotTimeout is the time after the parent block that passes the turn to
+  // propose the block to the next validator
+  SlotTimeout = 10 * time.Second
+)
+
+// parentTime returns the time of the last block or the genesis time before the
+// first block
+func (s *State) parentTime() time.Time {
+  if s.lastBlock.Number > 0 {
+    return s.lastBlock.Time
+  }
+  return s.genesisTime
+}
+
+// verifyTime verifies that the block time is after the parent block time and
+// is not too far ahead of the local clock, so the block time chosen by the
+// proposer is safe for the tx expiry, the proposer round, and the difficulty
+func (s *State) verifyTime(blk SigBlock) error {
+  if !blk.Time.After(s.parentTime()) ||
+    blk.Time.After(time.Now().Add(maxFutureBlockTime)) {
+    return fmt.Errorf("blk error: invalid block time\n%v", blk)
+  }
+  return nil
+}
+
+// SlotRound returns the round of the next block at the time. The round starts
+// at the parent block time and advances every slot timeout to let the next
+// validator take over a missed slot
+func (s *State) SlotRound(now time.Time) uint64 {
+  s.mtx.RLock()
+  defer s.mtx.RUnlock()
+  return s.slotRound(now)
+}
+
+func (s *State) slotRound(now time.Time) uint64 {
+  elapsed := now.Sub(s.parentTime())
+  if elapsed < 0 {
     return 0
-  })
+  }
+  return uint64(elapsed / SlotTimeout)
+}
+
+// verifyRound verifies that the block round has been reached by the block
+// time, so the proposer cannot skip the turn of other validators
+func (s *State) verifyRound(blk SigBlock) error {
+  if blk.Round > s.slotRound(blk.Time) {
+    return fmt.Errorf("blk error: invalid block round\n%v", blk)
+  }
+  return nil
+}
+
+// newBlock creates the unsigned block from the valid pending txs and the
+// valid pending evidence. The txs that exceed the block limits stay pending
+// for the next block
+func (s *State) newBlock() (Block, error) {
+  // The block time follows the parent block time of the proposer with a
+  // slightly ahead clock
+  s.blockTime = time.Now()
+  if !s.blockTime.After(s.parentTime()) {
+    s.blockTime = s.parentTime().Add(time.Millisecond)
+  }
+  pndTxs := feeOrder(slices.Collect(maps.Values(s.Pending.txs)))
   txs := make([]SigTx, 0, len(pndTxs))
-  for _, tx := range pndTxs {
+  size := blockOverhead(s.pendingEvidence())
+  skipped := make(map[Address]bool)
+  for _, ftx := range pndTxs {
+    tx, txSize := ftx.tx, ftx.size
+    if s.limits.MaxTxs > 0 && uint64(len(txs)) == s.limits.MaxTxs {
+      break
+    }
+    // The next txs of the account with the skipped tx are out of nonce order
+    if skipped[tx.From] ||
+      s.limits.MaxBytes > 0 && size + txSize > s.limits.MaxBytes {
+      skipped[tx.From] = true
+      continue
+    }
     err := s.ApplyTx(tx)
     if err != nil {
       fmt.Printf("tx error: rejected: %v\n", err)
       continue
     }
     txs = append(txs, tx)
+    size += txSize
   }
   if len(txs) == 0 {
-    return SigBlock{}, fmt.Errorf("empty list of valid pending transactions")
+    return Block{}, fmt.Errorf("empty list of valid pending transactions")
+  }
+  var evs []Evidence
+  for _, ev := range s.pendingEvidence() {
+    err := s.penalize(ev)
+    if err != nil {
+      fmt.Printf("evd error: rejected: %v\n", err)
+      continue
+    }
+    evs = append(evs, ev)
   }
   var parent Hash
   if s.lastBlock.Number == 0 {
@@ -173,26 +472,80 @@ func (s *State) CreateBlock(authority Account) (SigBlock, error) {
   } else {
     parent = s.lastBlock.Hash()
   }
-  blk, err := NewBlock(s.lastBlock.Number + 1, parent, txs)
+  stateRoot, err := StateRoot(s.balances, s.nonces)
+  if err != nil {
+    return Block{}, err
+  }
+  blk, err := NewBlock(s.lastBlock.Number + 1, parent, txs, stateRoot)
+  if err != nil {
+    return Block{}, err
+  }
+  blk.Time = s.blockTime
+  blk.Evidence = evs
+  return blk, nil
+}
+
+// CreateBlock creates the block in the round signed by the validator
+func (s *State) CreateBlock(validator Account, round uint64) (SigBlock, error) {
+  // The is no need to lock/unlock as the CreateBlock is always executed on the
+  // cloned state
+  s.proposer = validator.Address()
+  blk, err := s.newBlock()
   if err != nil {
     return SigBlock{}, err
   }
-  return authority.SignBlock(blk)
+  blk.Round = round
+  return validator.SignBlock(blk)
 }
 
 func (s *State) ApplyBlock(blk SigBlock) error {
   // The is no need to lock/unlock as the CreateBlock is always executed on the
   // cloned state
-  valid, err := VerifyBlock(blk, s.authority)
+  if s.consensus == ConsensusPoW {
+    err := s.verifyWork(blk)
+    if err != nil {
+      return err
+    }
+  } else {
+    err := VerifyNoWork(blk)
+    if err != nil {
+      return err
+    }
+    valid, err := VerifyBlock(blk, validatorAddrs(s.validators))
+    if err != nil {
+      return err
+    }
+    if !valid {
+      return fmt.Errorf("blk error: invalid block signature\n%v", blk)
+    }
+  }
+  if blk.Number != s.lastBlock.Number + 1 {
+    return fmt.Errorf("blk error: invalid block number\n%v", blk)
+  }
+  err := s.verifyTime(blk)
   if err != nil {
     return err
   }
-  if !valid {
-    return fmt.Errorf("blk error: invalid block signature\n%v", blk)
+  // The BFT round is bounded by the round of the commit instead
+  if s.consensus == ConsensusPoA {
+    err = s.verifyRound(blk)
+    if err != nil {
+      return err
+    }
   }
-  if blk.Number != s.lastBlock.Number + 1 {
-    return fmt.Errorf("blk error: invalid block number\n%v", blk)
+  err = s.verifyVersion(blk.Version)
+  if err != nil {
+    return fmt.Errorf("blk error: %v\n%v", err, blk)
   }
+  err = s.verifyLimits(blk)
+  if err != nil {
+    return err
+  }
+  proposer, err := BlockSigner(blk)
+  if err != nil {
+    return err
+  }
+  s.proposer = proposer
   var parent Hash
   if blk.Number == 1 {
     parent = s.genesisHash
@@ -202,7 +555,7 @@ func (s *State) ApplyBlock(blk SigBlock) error {
   if blk.Parent != parent {
     return fmt.Errorf("blk error: invalid parent hash\n%v", blk)
   }
-  merkleTree, err := MerkleHash(blk.Txs, TxHash, TxPairHash)
+  merkleTree, err := MerkleHash(blk.Txs, TxHash, MerklePairHash(blk.Version))
   if err != nil {
     return err
   }
@@ -210,12 +563,30 @@ func (s *State) ApplyBlock(blk SigBlock) error {
   if merkleRoot != blk.MerkleRoot {
     return fmt.Errorf("blk error: invalid Merkle root\n%v", blk)
   }
+  // The tx expiry is verified at the verified block time
+  s.blockTime = blk.Time
+  s.touched = make(map[Address]bool)
   for _, tx := range blk.Txs {
     err := s.ApplyTx(tx)
     if err != nil {
       return err
     }
   }
+  for _, ev := range blk.Evidence {
+    err := s.penalize(ev)
+    if err != nil {
+      return err
+    }
+  }
+  // The legacy block before the state roots does not commit to the state
+  legacy := blk.Version == LegacyEncoding && blk.StateRoot == Hash{}
+  stateRoot, err := StateRoot(s.balances, s.nonces)
+  if err != nil {
+    return err
+  }
+  if stateRoot != blk.StateRoot && !legacy {
+    return fmt.Errorf("blk error: invalid state root\n%v", blk)
+  }
   s.lastBlock = blk
   return nil
 }
@@ -230,3 +601,37 @@ func (s *State) ApplyBlockToState(blk SigBlock) error {
   fmt.Printf("=== Block state\n%v", s)
   return nil
 }
+
+// ReplayState reconstructs the state at the block number by re-applying the
+// blocks from the block store to the state from the genesis
+func ReplayState(
+  gen SigGenesis, blockStore BlockStore, number uint64,
+) (*State, error) {
+  state := NewState(gen)
+  if number == 0 {
+    return state, nil
+  }
+  blocks, closeBlocks, err := blockStore.BlocksBytes(1)
+  if err != nil {
+    return nil, err
+  }
+  defer closeBlocks()
+  for err, jblk := range blocks {
+    if err != nil {
+      return nil, err
+    }
+    var blk SigBlock
+    err = json.Unmarshal(jblk, &blk)
+    if err != nil {
+      return nil, err
+    }
+    err = state.ApplyBlock(blk)
+    if err != nil {
+      return nil, err
+    }
+    if blk.Number == number {
+      return state, nil
+    }
+  }
+  return nil, fmt.Errorf("block %v not found", number)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
 )
@@ -39,7 +40,7 @@ func TestApplyTx(t *testing.T) {
     t.Run(c.name, func(t *testing.T) {
       // Create and sign a transaction
       tx := chain.NewTx(
-        acc.Address(), chain.Address("to"), c.value,
+        chainName, acc.Address(), chain.Address("to"), c.value,
         pending.Nonce(acc.Address()) + c.nonceInc,
       )
       stx, err := acc.SignTx(tx)
@@ -73,7 +74,8 @@ func TestApplyTx(t *testing.T) {
     // Create and sign a transaction with the value amount that exceeds the
     // balance of the sender account
     tx := chain.NewTx(
-      acc.Address(), chain.Address("to"), 1000, pending.Nonce(acc.Address()) + 1,
+      chainName, acc.Address(), chain.Address("to"), 1000,
+      pending.Nonce(acc.Address()) + 1,
     )
     stx, err := acc.SignTx(tx)
     if err != nil {
@@ -95,7 +97,8 @@ func TestApplyTx(t *testing.T) {
     // Create and sign a transaction from the sender account, but signed with
     // the new account
     tx := chain.NewTx(
-      acc.Address(), chain.Address("to"), 12, pending.Nonce(acc.Address()) + 1,
+      chainName, acc.Address(), chain.Address("to"), 12,
+      pending.Nonce(acc.Address()) + 1,
     )
     stx, err := acc2.SignTx(tx)
     if err != nil {
@@ -108,6 +111,58 @@ func TestApplyTx(t *testing.T) {
       t.Errorf("expected invalid signature error, got none")
     }
   })
+  t.Run("tx of another chain", func(t *testing.T) {
+    // Create and sign a transaction for another chain
+    tx := chain.NewTx(
+      "another chain", acc.Address(), chain.Address("to"), 12,
+      pending.Nonce(acc.Address()) + 1,
+    )
+    stx, err := acc.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Apply the transaction of another chain to the pending state
+    err = pending.ApplyTx(stx)
+    // Verify that the replayed transaction is rejected
+    if err == nil {
+      t.Errorf("expected invalid chain error, got none")
+    }
+  })
+  t.Run("expired tx", func(t *testing.T) {
+    // Create and sign transactions that expire by the time and by the block
+    // number
+    past := time.Now().Add(-time.Minute)
+    cases := []struct{ name string; expiry chain.TxExpiry; valid bool }{
+      {"expired time", chain.TxExpiry{Time: past}, false},
+      {"valid until next block", chain.TxExpiry{Number: 1}, true},
+    }
+    for _, c := range cases {
+      tx := chain.NewTx(
+        chainName, acc.Address(), chain.Address("to"), 12,
+        pending.Nonce(acc.Address()) + 1,
+      )
+      tx.Expiry = &c.expiry
+      stx, err := acc.SignTx(tx)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Apply the transaction with the expiry to the pending state
+      err = pending.ApplyTx(stx)
+      // Verify that the expired transaction is rejected and the transaction
+      // valid in the next block is accepted
+      if c.valid && err != nil {
+        t.Errorf("%v: %v", c.name, err)
+      }
+      if !c.valid && err == nil {
+        t.Errorf("%v: expected expired transaction error, got none", c.name)
+      }
+      // Verify that the transaction valid until the block 1 expires in the
+      // block 2
+      if c.valid && !stx.Expired(2, time.Now()) {
+        t.Errorf("%v: expected expired transaction in block 2", c.name)
+      }
+    }
+  })
 }
 
 func TestApplyBlock(t *testing.T) {
@@ -140,7 +195,7 @@ func TestApplyBlock(t *testing.T) {
   for _, value := range []uint64{12, 1000, 34} {
     // Create and sign a transaction
     tx := chain.NewTx(
-      acc.Address(), chain.Address("to"), value,
+      chainName, acc.Address(), chain.Address("to"), value,
       pending.Nonce(acc.Address()) + 1,
     )
     stx, err := acc.SignTx(tx)
@@ -155,7 +210,7 @@ func TestApplyBlock(t *testing.T) {
   }
   // Create a new block on the cloned state
   clone := state.Clone()
-  blk, err := clone.CreateBlock(auth)
+  blk, err := clone.CreateBlock(auth, 0)
   if err != nil {
     t.Fatal(err)
   }
@@ -178,4 +233,223 @@ func TestApplyBlock(t *testing.T) {
   if got != exp {
     t.Errorf("invalid balance: expected %v, got %v", exp, got)
   }
+  // Verify that the block commits to the state root of the confirmed state
+  stateRoot, err := state.StateRoot()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if blk.StateRoot != stateRoot {
+    t.Errorf("invalid state root")
+  }
+  // Create and sign a block with a diverging state root
+  tx := chain.NewTx(
+    chainName, acc.Address(), chain.Address("to"), 1,
+    state.Nonce(acc.Address()) + 1,
+  )
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  divBlk, err := chain.NewBlock(
+    blk.Number + 1, blk.Hash(), []chain.SigTx{stx}, stateRoot,
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  sdivBlk, err := auth.SignBlock(divBlk)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the block with the diverging state root is rejected
+  clone = state.Clone()
+  err = clone.ApplyBlock(sdivBlk)
+  if err == nil {
+    t.Errorf("block with invalid state root applied")
+  }
+}
+
+func TestBlockTime(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(gen)
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the block with the tx that expires two hours later
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  tx.Expiry = &chain.TxExpiry{Time: time.Now().Add(2 * time.Hour)}
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blk, err := state.Clone().CreateBlock(auth, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  cases := []struct{ name string; time time.Time }{
+    {"before genesis", gen.Time.Add(-time.Second)},
+    {"far in future", time.Now().Add(time.Hour)},
+  }
+  for _, c := range cases {
+    // Re-sign the block with the block time chosen by the proposer
+    forged := blk.Block
+    forged.Time = c.time
+    sforged, err := auth.SignBlock(forged)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the block with the invalid block time is rejected
+    clone := state.Clone()
+    err = clone.ApplyBlock(sforged)
+    if err == nil {
+      t.Errorf("block with block time %v applied", c.name)
+    }
+  }
+  // Verify that the block with the valid block time is applied
+  clone := state.Clone()
+  err = clone.ApplyBlock(blk)
+  if err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestBlockRound(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(gen)
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the block with the tx in the first round
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blk, err := state.Clone().CreateBlock(auth, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  cases := []struct{ name string; round uint64; time time.Time; valid bool }{
+    {"unreached round", 12345, blk.Time, false},
+    {"round before slot timeout", 1, blk.Time, false},
+    {"round after slot timeout", 1, gen.Time.Add(chain.SlotTimeout), true},
+  }
+  for _, c := range cases {
+    // Re-sign the block with the round and the time chosen by the proposer
+    forged := blk.Block
+    forged.Round, forged.Time = c.round, c.time
+    sforged, err := auth.SignBlock(forged)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that only the round reached by the block time is accepted
+    clone := state.Clone()
+    err = clone.ApplyBlock(sforged)
+    if c.valid && err != nil {
+      t.Errorf("block with %v rejected: %v", c.name, err)
+    }
+    if !c.valid && err == nil {
+      t.Errorf("block with %v applied", c.name)
+    }
+  }
+}
+
+func TestApplyPendingTxs(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(gen)
+  // Re-create the authority account and the initial owner account
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, ownerBal := genesisAccount(gen)
+  path = filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  applyTx := func(value uint64) {
+    tx := chain.NewTx(
+      chainName, acc.Address(), chain.Address("to"), value,
+      state.Pending.Nonce(acc.Address()) + 1,
+    )
+    stx, err := acc.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.Pending.ApplyTx(stx)
+    if err != nil {
+      t.Fatal(err)
+    }
+  }
+  // Create the block with the first tx and apply the second tx to the pending
+  // state
+  applyTx(12)
+  blk, err := state.Clone().CreateBlock(auth, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  applyTx(34)
+  // Apply the block to the confirmed state
+  err = state.ApplyBlockToState(blk)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the pending state still reflects the second pending tx right
+  // after the block application
+  nonce := state.Pending.Nonce(acc.Address())
+  if nonce != 2 {
+    t.Errorf("invalid pending nonce: expected %v, got %v", 2, nonce)
+  }
+  balance, _ := state.Pending.Balance(acc.Address())
+  if balance != ownerBal - 12 - 34 {
+    t.Errorf(
+      "invalid pending balance: expected %v, got %v",
+      ownerBal - 12 - 34, balance,
+    )
+  }
 }
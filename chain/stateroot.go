@@ -0,0 +1,179 @@
+package chain
+
+import (
+	"slices"
+	"strings"
+)
+
+// AccState is a leaf of the state Merkle tree that commits to the account
+// balance and the account nonce
+type AccState struct {
+  Account Address `json:"account"`
+  Balance uint64 `json:"balance"`
+  Nonce uint64 `json:"nonce"`
+}
+
+func AccStateHash(acc AccState) Hash {
+  return keccak(acc.Encode())
+}
+
+// stateLeaves returns the account states sorted by the account address
+func stateLeaves(
+  balances map[Address]uint64, nonces map[Address]uint64,
+) []AccState {
+  accs := make([]Address, 0, len(balances))
+  for acc := range balances {
+    accs = append(accs, acc)
+  }
+  for acc := range nonces {
+    _, exist := balances[acc]
+    if !exist {
+      accs = append(accs, acc)
+    }
+  }
+  slices.Sort(accs)
+  leaves := make([]AccState, len(accs))
+  for i, acc := range accs {
+    leaves[i] = AccState{
+      Account: acc, Balance: balances[acc], Nonce: nonces[acc],
+    }
+  }
+  return leaves
+}
+
+// StateRoot deterministically commits to the balances and the nonces of all
+// accounts through the Merkle tree over the account states sorted by the
+// account address
+func StateRoot(
+  balances map[Address]uint64, nonces map[Address]uint64,
+) (Hash, error) {
+  leaves := stateLeaves(balances, nonces)
+  if len(leaves) == 0 {
+    return Hash{}, nil
+  }
+  merkleTree, err := MerkleHash(leaves, AccStateHash, PairHash)
+  if err != nil {
+    return Hash{}, err
+  }
+  return merkleTree[0], nil
+}
+
+func (s *State) StateRoot() (Hash, error) {
+  s.mtx.RLock()
+  defer s.mtx.RUnlock()
+  return StateRoot(s.balances, s.nonces)
+}
+
+// AccLeafProof is the Merkle path of the account state from the leaf to the
+// state root. The leaf index defines the position of the account state in the
+// sorted account states and the order of hashing with the sibling hashes
+type AccLeafProof struct {
+  Index uint64 `json:"index"`
+  Leaf AccState `json:"leaf"`
+  Path []Hash `json:"path"`
+}
+
+// AccProof proves the existence of the account state, or the non-existence of
+// the account by proving the adjacent account states around the account
+type AccProof struct {
+  Account Address `json:"account"`
+  Number uint64 `json:"number"`
+  Exist bool `json:"exist"`
+  Leaves []AccLeafProof `json:"leaves"`
+}
+
+func accLeafProof(
+  leaves []AccState, merkleTree []Hash, index int,
+) AccLeafProof {
+  path := make([]Hash, 0)
+  for i := len(merkleTree) / 2 + index; i > 0; i = (i - 1) / 2 {
+    if i % 2 == 1 {
+      path = append(path, merkleTree[i + 1])
+    } else {
+      path = append(path, merkleTree[i - 1])
+    }
+  }
+  return AccLeafProof{Index: uint64(index), Leaf: leaves[index], Path: path}
+}
+
+func (s *State) ProveAccount(acc Address) (AccProof, error) {
+  s.mtx.RLock()
+  defer s.mtx.RUnlock()
+  leaves := stateLeaves(s.balances, s.nonces)
+  merkleTree, err := MerkleHash(leaves, AccStateHash, PairHash)
+  if err != nil {
+    return AccProof{}, err
+  }
+  proof := AccProof{Account: acc, Number: s.lastBlock.Number}
+  i, exist := slices.BinarySearchFunc(
+    leaves, acc, func(leaf AccState, acc Address) int {
+      return strings.Compare(string(leaf.Account), string(acc))
+    },
+  )
+  if exist {
+    proof.Exist = true
+    proof.Leaves = []AccLeafProof{accLeafProof(leaves, merkleTree, i)}
+    return proof, nil
+  }
+  // The non-existing account is positioned between the adjacent leaves
+  if i > 0 {
+    proof.Leaves = append(proof.Leaves, accLeafProof(leaves, merkleTree, i - 1))
+  }
+  if i < len(leaves) {
+    proof.Leaves = append(proof.Leaves, accLeafProof(leaves, merkleTree, i))
+  }
+  return proof, nil
+}
+
+func (p AccLeafProof) root() (Hash, bool) {
+  hash, index := AccStateHash(p.Leaf), p.Index
+  for _, sibling := range p.Path {
+    if index % 2 == 0 {
+      hash = PairHash(hash, sibling)
+    } else {
+      hash = PairHash(sibling, hash)
+    }
+    index /= 2
+  }
+  return hash, index == 0
+}
+
+// last checks that all the right siblings on the Merkle path are empty
+func (p AccLeafProof) last() bool {
+  var nilHash Hash
+  index := p.Index
+  for _, sibling := range p.Path {
+    if index % 2 == 0 && sibling != nilHash {
+      return false
+    }
+    index /= 2
+  }
+  return true
+}
+
+func VerifyAccount(proof AccProof, stateRoot Hash) bool {
+  leaves := proof.Leaves
+  if len(leaves) == 0 || len(leaves) > 2 {
+    return false
+  }
+  for _, leaf := range leaves {
+    root, valid := leaf.root()
+    if !valid || root != stateRoot || len(leaf.Path) != len(leaves[0].Path) {
+      return false
+    }
+  }
+  acc := proof.Account
+  if proof.Exist {
+    return len(leaves) == 1 && leaves[0].Leaf.Account == acc
+  }
+  if len(leaves) == 2 {
+    left, right := leaves[0], leaves[1]
+    return left.Index + 1 == right.Index &&
+      left.Leaf.Account < acc && acc < right.Leaf.Account
+  }
+  leaf := leaves[0]
+  if acc < leaf.Leaf.Account {
+    return leaf.Index == 0
+  }
+  return acc > leaf.Leaf.Account && leaf.last()
+}
@@ -0,0 +1,128 @@
+package chain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestProveAccountVerifyAccount(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state from the genesis
+  state := chain.NewState(gen)
+  // Re-create the initial owner account and the authority account from the
+  // genesis
+  ownerAcc, _ := genesisAccount(gen)
+  path := filepath.Join(keyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  path = filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create and apply a block with transactions to several accounts that are
+  // sorted after the initial owner account
+  for i, to := range []chain.Address{"x1", "x3", "x5", "x7"} {
+    tx := chain.NewTx(
+      chainName, ownerAcc, to, uint64(i + 1), state.Pending.Nonce(ownerAcc) + 1,
+    )
+    stx, err := acc.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.Pending.ApplyTx(stx)
+    if err != nil {
+      t.Fatal(err)
+    }
+  }
+  clone := state.Clone()
+  blk, err := clone.CreateBlock(auth, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = state.ApplyBlockToState(blk)
+  if err != nil {
+    t.Fatal(err)
+  }
+  stateRoot := blk.StateRoot
+  t.Run("prove existing accounts", func(t *testing.T) {
+    for _, acc := range []chain.Address{ownerAcc, "x3", "x7"} {
+      // Prove the balance and the nonce of the existing account
+      proof, err := state.ProveAccount(acc)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that the account proof is valid against the block state root
+      if !proof.Exist || !chain.VerifyAccount(proof, stateRoot) {
+        t.Errorf("invalid account proof %v", acc)
+      }
+      balance, _ := state.Balance(acc)
+      if proof.Leaves[0].Leaf.Balance != balance {
+        t.Errorf(
+          "invalid balance: expected %v, got %v",
+          balance, proof.Leaves[0].Leaf.Balance,
+        )
+      }
+    }
+  })
+  t.Run("prove non-existing accounts", func(t *testing.T) {
+    // Prove the non-existence of accounts before the first account, between
+    // the adjacent accounts, and after the last account
+    for _, acc := range []chain.Address{"0", "x2", "y"} {
+      proof, err := state.ProveAccount(acc)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that the account non-existence proof is valid
+      if proof.Exist || !chain.VerifyAccount(proof, stateRoot) {
+        t.Errorf("invalid account non-existence proof %v", acc)
+      }
+    }
+  })
+  t.Run("reject tampered proofs", func(t *testing.T) {
+    // Tamper the balance of the existing account
+    proof, err := state.ProveAccount("x3")
+    if err != nil {
+      t.Fatal(err)
+    }
+    proof.Leaves[0].Leaf.Balance += 100
+    if chain.VerifyAccount(proof, stateRoot) {
+      t.Errorf("tampered account balance verified")
+    }
+    // Claim the non-existence of an existing account by skipping the existing
+    // account between the adjacent accounts
+    left, err := state.ProveAccount("x1")
+    if err != nil {
+      t.Fatal(err)
+    }
+    right, err := state.ProveAccount("x5")
+    if err != nil {
+      t.Fatal(err)
+    }
+    proof = chain.AccProof{
+      Account: "x3",
+      Leaves: []chain.AccLeafProof{left.Leaves[0], right.Leaves[0]},
+    }
+    if chain.VerifyAccount(proof, stateRoot) {
+      t.Errorf("skipped account non-existence verified")
+    }
+    // Claim the non-existence of an existing account after a non-last account
+    proof = chain.AccProof{
+      Account: "x6", Leaves: []chain.AccLeafProof{right.Leaves[0]},
+    }
+    if chain.VerifyAccount(proof, stateRoot) {
+      t.Errorf("non-last account non-existence verified")
+    }
+  })
+}
@@ -4,20 +4,17 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/dustinxie/ecc"
-	"golang.org/x/crypto/sha3"
 )
 
 type Hash [32]byte
 
 func NewHash(val any) Hash {
   jval, _ := json.Marshal(val)
-  state := sha3.NewLegacyKeccak256()
-  _, _ = state.Write(jval)
-  hash := state.Sum(nil)
-  return Hash(hash)
+  return keccak(jval)
 }
 
 func (h Hash) String() string {
@@ -44,25 +41,114 @@ func DecodeHash(str string) (Hash, error) {
   return hash, err
 }
 
+type TxKind uint64
+
+const (
+  TxTransfer TxKind = 0
+  TxValidatorAdd TxKind = 1
+  TxValidatorRemove TxKind = 2
+  TxValidatorPower TxKind = 3
+)
+
+func NewTxKind(kindStr string) (TxKind, error) {
+  switch kindStr {
+  case "transfer":
+    return TxTransfer, nil
+  case "add":
+    return TxValidatorAdd, nil
+  case "remove":
+    return TxValidatorRemove, nil
+  case "power":
+    return TxValidatorPower, nil
+  default:
+    return 0, fmt.Errorf("unsupported tx kind: %v", kindStr)
+  }
+}
+
+func (k TxKind) String() string {
+  switch k {
+  case TxValidatorAdd:
+    return "add"
+  case TxValidatorRemove:
+    return "remove"
+  case TxValidatorPower:
+    return "power"
+  default:
+    return "transfer"
+  }
+}
+
+// TxExpiry limits the validity of the tx to the block number, to the time, or
+// to both. The zero number and the zero time do not limit the tx
+type TxExpiry struct {
+  Number uint64 `json:"number,omitempty"`
+  Time time.Time `json:"time"`
+}
+
+// Tx is either the value transfer or the validator set change. The validator
+// tx adds, removes, or changes the voting power of the validator in the To
+// field. The chain name is committed into the tx hash, so the tx signed for
+// one chain is not valid on another chain. The legacy tx without the chain
+// name is valid only on the legacy chain. The tx with the expiry is not valid
+// in the blocks after the expiry
 type Tx struct {
+  Version uint64 `json:"version,omitempty"`
+  Chain string `json:"chain,omitempty"`
+  Kind TxKind `json:"kind,omitempty"`
   From Address `json:"from"`
   To Address `json:"to"`
   Value uint64 `json:"value"`
+  Fee uint64 `json:"fee,omitempty"`
+  Power uint64 `json:"power,omitempty"`
   Nonce uint64 `json:"nonce"`
   Time time.Time `json:"time"`
+  Expiry *TxExpiry `json:"expiry,omitempty"`
+}
+
+func NewTx(chain string, from, to Address, value, nonce uint64) Tx {
+  return Tx{
+    Version: EncodingVersion, Chain: chain, From: from, To: to, Value: value,
+    Nonce: nonce, Time: time.Now(),
+  }
+}
+
+func NewValidatorTx(
+  chain string, kind TxKind, from, validator Address, power, nonce uint64,
+) Tx {
+  return Tx{
+    Version: EncodingVersion, Chain: chain, Kind: kind, From: from,
+    To: validator, Power: power, Nonce: nonce, Time: time.Now(),
+  }
 }
 
-func NewTx(from, to Address, value, nonce uint64) Tx {
-  return Tx{From: from, To: to, Value: value, Nonce: nonce, Time: time.Now()}
+// Expired returns true, when the tx is not valid in the block with the number
+// and the time
+func (t Tx) Expired(number uint64, time time.Time) bool {
+  if t.Expiry == nil {
+    return false
+  }
+  return t.Expiry.Number > 0 && number > t.Expiry.Number ||
+    !t.Expiry.Time.IsZero() && time.After(t.Expiry.Time)
 }
 
 func (t Tx) Hash() Hash {
-  return NewHash(t)
+  if t.Version == LegacyEncoding {
+    return NewHash(t)
+  }
+  return keccak(t.Encode())
+}
+
+// Approval is the signature of the tx by the validator approving the validator
+// set change
+type Approval struct {
+  Validator Address `json:"validator"`
+  Sig []byte `json:"sig"`
 }
 
 type SigTx struct {
   Tx
   Sig []byte `json:"sig"`
+  Approvals []Approval `json:"approvals,omitempty"`
 }
 
 func NewSigTx(tx Tx, sig []byte) SigTx {
@@ -70,10 +156,19 @@ func NewSigTx(tx Tx, sig []byte) SigTx {
 }
 
 func (t SigTx) Hash() Hash {
-  return NewHash(t)
+  if t.Version == LegacyEncoding {
+    return NewHash(t)
+  }
+  return keccak(t.Encode())
 }
 
 func (t SigTx) String() string {
+  if t.Kind != TxTransfer {
+    return fmt.Sprintf(
+      "tx  %.7s: %-7.7s %-6v %-7.7s %8d %8d",
+      t.Hash(), t.From, t.Kind, t.To, t.Power, t.Nonce,
+    )
+  }
   return fmt.Sprintf(
     "tx  %.7s: %-7.7s -> %-7.7s %8d %8d",
     t.Hash(), t.From, t.To, t.Value, t.Nonce,
@@ -91,9 +186,10 @@ func VerifyTx(tx SigTx) (bool, error) {
 }
 
 func TxHash(tx SigTx) Hash {
-  return NewHash(tx)
+  return tx.Hash()
 }
 
+// TxPairHash pairs the Merkle tree hashes of the legacy encoding
 func TxPairHash(l, r Hash) Hash {
   var nilHash Hash
   if r == nilHash {
@@ -124,3 +220,65 @@ func (t SearchTx) String() string {
     t.SigTx, t.BlockNumber, t.BlockHash, t.MerkleRoot,
   )
 }
+
+// PendingTx is the tx in the mempool. The ready tx is applied to the pending
+// state, the future tx waits for the nonce gap to be filled
+type PendingTx struct {
+  SigTx
+  Ready bool `json:"ready"`
+}
+
+func (t PendingTx) String() string {
+  queue := "future"
+  if t.Ready {
+    queue = "ready"
+  }
+  return fmt.Sprintf("%v    fee %8d   %v", t.SigTx, t.Fee, queue)
+}
+
+// PendingStats summarizes the pending txs of the account in the mempool
+type PendingStats struct {
+  Account Address `json:"account"`
+  Count uint64 `json:"count"`
+  Bytes uint64 `json:"bytes"`
+  MinFee uint64 `json:"minFee"`
+  MaxFee uint64 `json:"maxFee"`
+  MinNonce uint64 `json:"minNonce"`
+  MaxNonce uint64 `json:"maxNonce"`
+}
+
+// NewPendingStats summarizes the pending txs per account in the account order
+func NewPendingStats(txs []PendingTx) []PendingStats {
+  accStats := make(map[Address]*PendingStats)
+  var accs []Address
+  for _, tx := range txs {
+    stats, exist := accStats[tx.From]
+    if !exist {
+      stats = &PendingStats{
+        Account: tx.From, MinFee: tx.Fee, MaxFee: tx.Fee,
+        MinNonce: tx.Nonce, MaxNonce: tx.Nonce,
+      }
+      accStats[tx.From] = stats
+      accs = append(accs, tx.From)
+    }
+    stats.Count++
+    stats.Bytes += TxSize(tx.SigTx)
+    stats.MinFee = min(stats.MinFee, tx.Fee)
+    stats.MaxFee = max(stats.MaxFee, tx.Fee)
+    stats.MinNonce = min(stats.MinNonce, tx.Nonce)
+    stats.MaxNonce = max(stats.MaxNonce, tx.Nonce)
+  }
+  slices.Sort(accs)
+  stats := make([]PendingStats, 0, len(accs))
+  for _, acc := range accs {
+    stats = append(stats, *accStats[acc])
+  }
+  return stats
+}
+
+func (s PendingStats) String() string {
+  return fmt.Sprintf(
+    "acc %.7s   txs %4d   bytes %6d   fee %d-%d   nonce %d-%d",
+    s.Account, s.Count, s.Bytes, s.MinFee, s.MaxFee, s.MinNonce, s.MaxNonce,
+  )
+}
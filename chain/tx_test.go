@@ -13,7 +13,7 @@ func TestTxSignTxVerifyTx(t *testing.T) {
     t.Fatal(err)
   }
   // Create and sign a transaction
-  tx := chain.NewTx(acc.Address(), chain.Address("to"), 12, 1)
+  tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
   stx, err := acc.SignTx(tx)
   if err != nil {
     t.Fatal(err)
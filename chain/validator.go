@@ -0,0 +1,141 @@
+package chain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dustinxie/ecc"
+)
+
+// Validator is the validator address with the voting power of the validator
+type Validator struct {
+  Address Address `json:"address"`
+  Power uint64 `json:"power"`
+}
+
+func (v Validator) String() string {
+  return fmt.Sprintf("val %-7.7s %8d", v.Address, v.Power)
+}
+
+// NewValidators returns the validator set with the unit voting power of every
+// validator
+func NewValidators(addrs []Address) []Validator {
+  vals := make([]Validator, len(addrs))
+  for i, addr := range addrs {
+    vals[i] = Validator{Address: addr, Power: 1}
+  }
+  return vals
+}
+
+func validatorAddrs(vals []Validator) []Address {
+  addrs := make([]Address, len(vals))
+  for i, val := range vals {
+    addrs[i] = val.Address
+  }
+  return addrs
+}
+
+func validatorIndex(vals []Validator, addr Address) int {
+  return slices.IndexFunc(vals, func(val Validator) bool {
+    return val.Address == addr
+  })
+}
+
+func TotalPower(vals []Validator) uint64 {
+  var power uint64
+  for _, val := range vals {
+    power += val.Power
+  }
+  return power
+}
+
+// Proposer returns the validator whose turn it is to propose the block at the
+// block number. Every next round passes the turn to the next validator in the
+// ordered validator set to take over a missed slot
+func Proposer(validators []Address, number, round uint64) Address {
+  if len(validators) == 0 {
+    return ""
+  }
+  return validators[(number - 1 + round) % uint64(len(validators))]
+}
+
+// verifyApprovals verifies that the validator tx is approved by the validators
+// with more than 2/3 of the total voting power. The sender validator approves
+// the tx with the tx signature
+func verifyApprovals(tx SigTx, vals []Validator) error {
+  if validatorIndex(vals, tx.From) == -1 {
+    return fmt.Errorf("tx error: sender is not a validator\n%v\n", tx)
+  }
+  approved := map[Address]bool{tx.From: true}
+  hash := tx.Tx.Hash().Bytes()
+  for _, appr := range tx.Approvals {
+    pub, err := ecc.RecoverPubkey("P-256k1", hash, appr.Sig)
+    if err != nil {
+      return err
+    }
+    if NewAddress(pub) != appr.Validator ||
+      validatorIndex(vals, appr.Validator) == -1 {
+      return fmt.Errorf("tx error: invalid approval\n%v\n", tx)
+    }
+    approved[appr.Validator] = true
+  }
+  var power uint64
+  for _, val := range vals {
+    if approved[val.Address] {
+      power += val.Power
+    }
+  }
+  if power < Quorum(TotalPower(vals)) {
+    return fmt.Errorf(
+      "tx error: insufficient approvals %v of %v\n%v\n",
+      power, TotalPower(vals), tx,
+    )
+  }
+  return nil
+}
+
+// applyValidatorTx applies the validator set change. The change takes effect
+// from the next block
+func (s *State) applyValidatorTx(tx SigTx) error {
+  if tx.Value != 0 {
+    return fmt.Errorf("tx error: validator tx with value\n%v\n", tx)
+  }
+  err := verifyApprovals(tx, s.validators)
+  if err != nil {
+    return err
+  }
+  vals := slices.Clone(s.validators)
+  i := validatorIndex(vals, tx.To)
+  switch tx.Kind {
+  case TxValidatorAdd:
+    if i != -1 {
+      return fmt.Errorf("tx error: validator already exists\n%v\n", tx)
+    }
+    _, jailed := s.jailed[tx.To]
+    if jailed {
+      return fmt.Errorf("tx error: validator is jailed\n%v\n", tx)
+    }
+    power := max(tx.Power, 1)
+    vals = append(vals, Validator{Address: tx.To, Power: power})
+  case TxValidatorRemove:
+    if i == -1 {
+      return fmt.Errorf("tx error: validator does not exist\n%v\n", tx)
+    }
+    if len(vals) == 1 {
+      return fmt.Errorf("tx error: cannot remove last validator\n%v\n", tx)
+    }
+    vals = slices.Delete(vals, i, i + 1)
+  case TxValidatorPower:
+    if i == -1 {
+      return fmt.Errorf("tx error: validator does not exist\n%v\n", tx)
+    }
+    if tx.Power == 0 {
+      return fmt.Errorf("tx error: zero voting power\n%v\n", tx)
+    }
+    vals[i].Power = tx.Power
+  default:
+    return fmt.Errorf("tx error: unsupported tx kind\n%v\n", tx)
+  }
+  s.validators = vals
+  return nil
+}
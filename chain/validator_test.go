@@ -0,0 +1,165 @@
+package chain_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+func TestProposerRoundRobin(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  // Create the validator accounts and the initial owner account
+  vals := make([]chain.Account, 3)
+  for i := range vals {
+    val, err := createAccount()
+    if err != nil {
+      t.Fatal(err)
+    }
+    vals[i] = val
+  }
+  acc, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the genesis with the ordered validator set
+  gen := chain.NewGenesis(
+    chainName, vals[0].Address(), acc.Address(), ownerBalance,
+    vals[1].Address(), vals[2].Address(),
+  )
+  // Start the genesis one slot timeout earlier to let the next validator take
+  // over the missed slot in the next round
+  gen.Time = gen.Time.Add(-chain.SlotTimeout)
+  sgen, err := vals[0].SignGen(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  validators := sgen.ValidatorSet()
+  t.Run("rotate proposers", func(t *testing.T) {
+    // Verify that the proposer rotates over the block numbers and the rounds
+    cases := []struct{
+      number, round uint64
+      exp int
+    }{
+      {1, 0, 0}, {2, 0, 1}, {3, 0, 2}, {4, 0, 0}, {1, 1, 1}, {3, 1, 0},
+    }
+    for _, c := range cases {
+      got := chain.Proposer(validators, c.number, c.round)
+      if got != vals[c.exp].Address() {
+        t.Errorf(
+          "invalid proposer for block %v round %v: expected %v, got %v",
+          c.number, c.round, vals[c.exp].Address(), got,
+        )
+      }
+    }
+  })
+  t.Run("accept block only from validator in turn", func(t *testing.T) {
+    // Create and apply a transaction to the pending state
+    state := chain.NewState(sgen)
+    tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+    stx, err := acc.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.Pending.ApplyTx(stx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the block from the validator out of turn is rejected
+    clone := state.Clone()
+    blk, err := clone.CreateBlock(vals[1], 0)
+    if err != nil {
+      t.Fatal(err)
+    }
+    clone = state.Clone()
+    err = clone.ApplyBlock(blk)
+    if err == nil {
+      t.Errorf("block from validator out of turn applied")
+    }
+    // Verify that the next validator takes over the missed slot in the next
+    // round
+    clone = state.Clone()
+    blk, err = clone.CreateBlock(vals[1], 1)
+    if err != nil {
+      t.Fatal(err)
+    }
+    clone = state.Clone()
+    err = clone.ApplyBlock(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+  })
+}
+
+func TestValidatorTx(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  // Create the validator accounts and the initial owner account
+  vals := make([]chain.Account, 2)
+  for i := range vals {
+    val, err := createAccount()
+    if err != nil {
+      t.Fatal(err)
+    }
+    vals[i] = val
+  }
+  acc, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the genesis with two validators of the unit voting power
+  gen := chain.NewGenesis(
+    chainName, vals[0].Address(), acc.Address(), ownerBalance,
+    vals[1].Address(),
+  )
+  sgen, err := vals[0].SignGen(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  cases := []struct{
+    name string
+    kind chain.TxKind
+    from chain.Account
+    validator chain.Address
+    approvers []chain.Account
+    valid bool
+  }{
+    {"sender is not a validator", chain.TxValidatorAdd, acc,
+      acc.Address(), vals, false},
+    {"insufficient approvals", chain.TxValidatorAdd, vals[0],
+      acc.Address(), nil, false},
+    {"approved validator add", chain.TxValidatorAdd, vals[0],
+      acc.Address(), vals[1:], true},
+    {"remove unknown validator", chain.TxValidatorRemove, vals[0],
+      chain.Address("unknown"), vals[1:], false},
+    {"approved validator remove", chain.TxValidatorRemove, vals[0],
+      vals[1].Address(), vals[1:], true},
+  }
+  for _, c := range cases {
+    t.Run(c.name, func(t *testing.T) {
+      // Sign the validator tx and approve the tx by the approvers
+      state := chain.NewState(sgen)
+      tx := chain.NewValidatorTx(
+        chainName, c.kind, c.from.Address(), c.validator, 1, 1,
+      )
+      stx, err := c.from.SignTx(tx)
+      if err != nil {
+        t.Fatal(err)
+      }
+      for _, appr := range c.approvers {
+        stx, err = appr.ApproveTx(stx)
+        if err != nil {
+          t.Fatal(err)
+        }
+      }
+      // Verify that only the tx approved by the validators with more than 2/3
+      // of the voting power is applied
+      err = state.ApplyTx(stx)
+      if c.valid && err != nil {
+        t.Errorf("valid validator tx rejected: %v", err)
+      }
+      if !c.valid && err == nil {
+        t.Errorf("invalid validator tx applied")
+      }
+    })
+  }
+}
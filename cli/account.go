@@ -2,9 +2,11 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
 	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -15,7 +17,10 @@ func accountCmd(ctx context.Context) *cobra.Command {
     Use: "account",
     Short: "Manages accounts on the blockchain",
   }
-  cmd.AddCommand(accountCreateCmd(ctx), accountBalanceCmd(ctx))
+  cmd.AddCommand(
+    accountCreateCmd(ctx), accountBalanceCmd(ctx), accountNonceCmd(ctx),
+    accountProveCmd(ctx), accountVerifyCmd(ctx), accountHistoryCmd(ctx),
+  )
   return cmd
 }
 
@@ -58,7 +63,9 @@ func accountCreateCmd(ctx context.Context) *cobra.Command {
   return cmd
 }
 
-func grpcAccountBalance(ctx context.Context, addr, acc string) (uint64, error) {
+func grpcAccountBalance(
+  ctx context.Context, addr, acc string, number uint64,
+) (uint64, error) {
   conn, err := grpc.NewClient(
     addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
   )
@@ -67,7 +74,7 @@ func grpcAccountBalance(ctx context.Context, addr, acc string) (uint64, error) {
   }
   defer conn.Close()
   cln := rpc.NewAccountClient(conn)
-  req := &rpc.AccountBalanceReq{Address: acc}
+  req := &rpc.AccountBalanceReq{Address: acc, Number: number}
   res, err := cln.AccountBalance(ctx, req)
   if err != nil {
     return 0, err
@@ -82,7 +89,8 @@ func accountBalanceCmd(ctx context.Context) *cobra.Command {
     RunE: func(cmd *cobra.Command, _ []string) error {
       addr, _ := cmd.Flags().GetString("node")
       acc, _ := cmd.Flags().GetString("account")
-      balance, err := grpcAccountBalance(ctx, addr, acc)
+      number, _ := cmd.Flags().GetUint64("at")
+      balance, err := grpcAccountBalance(ctx, addr, acc, number)
       if err != nil {
         return err
       }
@@ -91,6 +99,208 @@ func accountBalanceCmd(ctx context.Context) *cobra.Command {
     },
   }
   cmd.Flags().String("account", "", "account address")
+  cmd.Flags().Uint64("at", 0, "block number, the last block by default")
+  _ = cmd.MarkFlagRequired("account")
+  return cmd
+}
+
+func grpcAccountNonce(
+  ctx context.Context, addr, acc string, number uint64,
+) (uint64, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return 0, err
+  }
+  defer conn.Close()
+  cln := rpc.NewAccountClient(conn)
+  req := &rpc.AccountNonceReq{Address: acc, Number: number}
+  res, err := cln.AccountNonce(ctx, req)
+  if err != nil {
+    return 0, err
+  }
+  return res.Nonce, nil
+}
+
+func accountNonceCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "nonce",
+    Short: "Returns the nonce of an account",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      acc, _ := cmd.Flags().GetString("account")
+      number, _ := cmd.Flags().GetUint64("at")
+      nonce, err := grpcAccountNonce(ctx, addr, acc, number)
+      if err != nil {
+        return err
+      }
+      fmt.Printf("acc %v: nonce %v\n", acc, nonce)
+      return nil
+    },
+  }
+  cmd.Flags().String("account", "", "account address")
+  cmd.Flags().Uint64("at", 0, "block number, the last block by default")
+  _ = cmd.MarkFlagRequired("account")
+  return cmd
+}
+
+func grpcAccountProve(
+  ctx context.Context, addr, acc string, number uint64,
+) ([]byte, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewAccountClient(conn)
+  req := &rpc.AccountProveReq{Address: acc, Number: number}
+  res, err := cln.AccountProve(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  return res.AccountProof, nil
+}
+
+func accountProveCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "prove",
+    Short:
+    "Receives Merkle proof of account balance and nonce at a block number",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      acc, _ := cmd.Flags().GetString("account")
+      number, _ := cmd.Flags().GetUint64("number")
+      accProof, err := grpcAccountProve(ctx, addr, acc, number)
+      if err != nil {
+        return err
+      }
+      fmt.Printf("%s\n", accProof)
+      return nil
+    },
+  }
+  cmd.Flags().String("account", "", "account address")
+  cmd.Flags().Uint64("number", 0, "block number, the last block by default")
+  _ = cmd.MarkFlagRequired("account")
+  return cmd
+}
+
+func grpcAccountVerify(
+  ctx context.Context, addr, acc, accProof, stateRoot string,
+) (*rpc.AccountVerifyRes, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewAccountClient(conn)
+  req := &rpc.AccountVerifyReq{
+    Address: acc, AccountProof: []byte(accProof), StateRoot: stateRoot,
+  }
+  return cln.AccountVerify(ctx, req)
+}
+
+func accountVerifyCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "verify",
+    Short: "Verifies Merkle proof of account against state root",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      acc, _ := cmd.Flags().GetString("account")
+      accProof, _ := cmd.Flags().GetString("accproof")
+      stateRoot, _ := cmd.Flags().GetString("stateroot")
+      res, err := grpcAccountVerify(ctx, addr, acc, accProof, stateRoot)
+      if err != nil {
+        return err
+      }
+      switch {
+      case !res.Valid:
+        fmt.Printf("acc %v INVALID\n", acc)
+      case !res.Exist:
+        fmt.Printf("acc %v valid: does not exist\n", acc)
+      default:
+        fmt.Printf(
+          "acc %v valid: balance %v, nonce %v\n", acc, res.Balance, res.Nonce,
+        )
+      }
+      return nil
+    },
+  }
+  cmd.Flags().String("account", "", "account address")
+  cmd.Flags().String("accproof", "", "account Merkle proof")
+  cmd.Flags().String("stateroot", "", "state root")
+  _ = cmd.MarkFlagRequired("account")
+  _ = cmd.MarkFlagRequired("accproof")
+  _ = cmd.MarkFlagRequired("stateroot")
+  return cmd
+}
+
+func grpcAccountHistory(
+  ctx context.Context, addr string, req *rpc.AccountHistoryReq,
+) ([]chain.SearchTx, string, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, "", err
+  }
+  defer conn.Close()
+  cln := rpc.NewAccountClient(conn)
+  res, err := cln.AccountHistory(ctx, req)
+  if err != nil {
+    return nil, "", err
+  }
+  txs := make([]chain.SearchTx, len(res.Txs))
+  for i, jtx := range res.Txs {
+    err = json.Unmarshal(jtx, &txs[i])
+    if err != nil {
+      return nil, "", err
+    }
+  }
+  return txs, res.Cursor, nil
+}
+
+func accountHistoryCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "history",
+    Short: "Lists incoming and outgoing transactions of an account",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      req := &rpc.AccountHistoryReq{}
+      req.Address, _ = cmd.Flags().GetString("account")
+      req.Direction, _ = cmd.Flags().GetString("direction")
+      req.FromBlock, _ = cmd.Flags().GetUint64("from")
+      req.ToBlock, _ = cmd.Flags().GetUint64("to")
+      req.Limit, _ = cmd.Flags().GetUint32("limit")
+      req.Cursor, _ = cmd.Flags().GetString("cursor")
+      txs, cursor, err := grpcAccountHistory(ctx, addr, req)
+      if err != nil {
+        return err
+      }
+      if len(txs) == 0 {
+        fmt.Println("no transactions found")
+      }
+      for _, tx := range txs {
+        fmt.Printf("blk %v %s\n", tx.BlockNumber, tx.BlockHash)
+        fmt.Printf("tx  %s\n", tx.Hash())
+        fmt.Printf("%v\n", tx)
+      }
+      if len(cursor) > 0 {
+        fmt.Printf("next cursor %v\n", cursor)
+      }
+      return nil
+    },
+  }
+  cmd.Flags().String("account", "", "account address")
+  cmd.Flags().String("direction", "", "in, out, or both by default")
+  cmd.Flags().Uint64("from", 0, "first block number")
+  cmd.Flags().Uint64("to", 0, "last block number, the last block by default")
+  cmd.Flags().Uint32("limit", 0, "maximum number of txs, 100 by default")
+  cmd.Flags().String("cursor", "", "cursor from the previous page")
   _ = cmd.MarkFlagRequired("account")
   return cmd
 }
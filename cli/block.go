@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
@@ -18,7 +19,10 @@ func blockCmd(ctx context.Context) *cobra.Command {
     Use: "block",
     Short: "Queries blocks on the blockchain",
   }
-  cmd.AddCommand(blockSearchCmd(ctx))
+  cmd.AddCommand(
+    blockSearchCmd(ctx), blockRangeCmd(ctx), blockLatestCmd(ctx),
+    blockTailCmd(ctx),
+  )
   return cmd
 }
 
@@ -83,9 +87,7 @@ func blockSearchCmd(ctx context.Context) *cobra.Command {
           return err
         }
         found = true
-        fmt.Printf("blk %s\n", blk.Hash())
-        fmt.Printf("mrk %s\n", blk.MerkleRoot)
-        fmt.Printf("%v", blk)
+        printBlock(blk)
       }
       if !found {
         fmt.Println("no blocks found")
@@ -100,3 +102,185 @@ func blockSearchCmd(ctx context.Context) *cobra.Command {
   cmd.MarkFlagsOneRequired("number", "hash", "parent")
   return cmd
 }
+
+// blockRangeLimit is the maximum number of blocks returned by the node in
+// one range request
+const blockRangeLimit = 1000
+
+func printBlock(blk chain.SigBlock) {
+  fmt.Printf("blk %s\n", blk.Hash())
+  fmt.Printf("mrk %s\n", blk.MerkleRoot)
+  fmt.Printf("%v", blk)
+}
+
+func grpcBlockRange(
+  ctx context.Context, addr string, from, to uint64, limit uint32,
+) (func(yield func(err error, blk chain.SigBlock) bool), func(), error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, nil, err
+  }
+  close := func() {
+    conn.Close()
+  }
+  cln := rpc.NewBlockClient(conn)
+  req := &rpc.BlockRangeReq{From: from, To: to, Limit: limit}
+  stream, err := cln.BlockRange(ctx, req)
+  if err != nil {
+    return nil, nil, err
+  }
+  more := true
+  blocks := func(yield func(err error, blk chain.SigBlock) bool) {
+    for more {
+      res, err := stream.Recv()
+      if err == io.EOF {
+        return
+      }
+      if err != nil {
+        yield(err, chain.SigBlock{})
+        return
+      }
+      var blk chain.SigBlock
+      err = json.Unmarshal(res.Block, &blk)
+      if err != nil {
+        yield(err, chain.SigBlock{})
+        return
+      }
+      more = yield(nil, blk)
+    }
+  }
+  return blocks, close, nil
+}
+
+// printBlockRange prints the blocks from the first block number to the last
+// block number and returns the last printed block number
+func printBlockRange(
+  ctx context.Context, addr string, from, to uint64, limit uint32,
+) (uint64, error) {
+  blocks, closeBlocks, err := grpcBlockRange(ctx, addr, from, to, limit)
+  if err != nil {
+    return 0, err
+  }
+  defer closeBlocks()
+  var last uint64
+  for err, blk := range blocks {
+    if err != nil {
+      return 0, err
+    }
+    printBlock(blk)
+    last = blk.Number
+  }
+  return last, nil
+}
+
+func blockRangeCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "range",
+    Short: "Lists blocks in the range of block numbers",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      from, _ := cmd.Flags().GetUint64("from")
+      to, _ := cmd.Flags().GetUint64("to")
+      limit, _ := cmd.Flags().GetUint32("limit")
+      last, err := printBlockRange(ctx, addr, from, to, limit)
+      if err != nil {
+        return err
+      }
+      if last == 0 {
+        fmt.Println("no blocks found")
+      }
+      return nil
+    },
+  }
+  cmd.Flags().Uint64("from", 1, "first block number")
+  cmd.Flags().Uint64("to", 0, "last block number, the last block by default")
+  cmd.Flags().Uint32("limit", 0, "maximum number of blocks, 100 by default")
+  return cmd
+}
+
+func grpcBlockLatest(ctx context.Context, addr string) (chain.SigBlock, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  defer conn.Close()
+  cln := rpc.NewBlockClient(conn)
+  req := &rpc.BlockLatestReq{}
+  res, err := cln.BlockLatest(ctx, req)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  var blk chain.SigBlock
+  err = json.Unmarshal(res.Block, &blk)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  return blk, nil
+}
+
+func blockLatestCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "latest",
+    Short: "Returns the last confirmed block",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      blk, err := grpcBlockLatest(ctx, addr)
+      if err != nil {
+        return err
+      }
+      printBlock(blk)
+      return nil
+    },
+  }
+  return cmd
+}
+
+func blockTailCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "tail",
+    Short: "Lists the last confirmed blocks and optionally follows new blocks",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      count, _ := cmd.Flags().GetUint64("count")
+      follow, _ := cmd.Flags().GetBool("follow")
+      period, _ := cmd.Flags().GetDuration("period")
+      latest, err := grpcBlockLatest(ctx, addr)
+      if err != nil {
+        return err
+      }
+      last := latest.Number - min(count, latest.Number)
+      for {
+        // Print the blocks confirmed after the last printed block
+        if latest.Number > last {
+          number, err := printBlockRange(
+            ctx, addr, last + 1, latest.Number, blockRangeLimit,
+          )
+          if err != nil {
+            return err
+          }
+          last = max(last, number)
+        }
+        if !follow {
+          return nil
+        }
+        select {
+        case <- ctx.Done():
+          return nil
+        case <- time.After(period):
+        }
+        latest, err = grpcBlockLatest(ctx, addr)
+        if err != nil {
+          return err
+        }
+      }
+    },
+  }
+  cmd.Flags().Uint64("count", 10, "number of last blocks")
+  cmd.Flags().Bool("follow", false, "follow new blocks")
+  cmd.Flags().Duration("period", time.Second, "polling period for new blocks")
+  return cmd
+}
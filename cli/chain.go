@@ -16,6 +16,9 @@ func ChainCmd(ctx context.Context) *cobra.Command {
   }
   cmd.PersistentFlags().String("node", "", "target node address host:port")
   _ = cmd.MarkFlagRequired("node")
-  cmd.AddCommand(nodeCmd(ctx), accountCmd(ctx), txCmd(ctx), blockCmd(ctx))
+  cmd.AddCommand(
+    nodeCmd(ctx), accountCmd(ctx), txCmd(ctx), blockCmd(ctx),
+    validatorCmd(ctx), mempoolCmd(ctx),
+  )
   return cmd
 }
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func mempoolCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "mempool",
+    Short: "Inspects the pending transactions in the mempool",
+  }
+  cmd.AddCommand(mempoolListCmd(ctx), mempoolGetCmd(ctx), mempoolStatsCmd(ctx))
+  return cmd
+}
+
+func grpcMempoolList(
+  ctx context.Context, addr, from, to string,
+) ([]chain.PendingTx, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewMempoolClient(conn)
+  req := &rpc.MempoolListReq{From: from, To: to}
+  res, err := cln.MempoolList(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  var txs []chain.PendingTx
+  err = json.Unmarshal(res.Txs, &txs)
+  return txs, err
+}
+
+func mempoolListCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "list",
+    Short: "Lists the pending transactions by the from and to address",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      from, _ := cmd.Flags().GetString("from")
+      to, _ := cmd.Flags().GetString("to")
+      txs, err := grpcMempoolList(ctx, addr, from, to)
+      if err != nil {
+        return err
+      }
+      for _, tx := range txs {
+        fmt.Printf("%v\n", tx)
+      }
+      if len(txs) == 0 {
+        fmt.Println("no pending transactions")
+      }
+      return nil
+    },
+  }
+  cmd.Flags().String("from", "", "sender address")
+  cmd.Flags().String("to", "", "recipient address")
+  return cmd
+}
+
+func grpcMempoolGet(
+  ctx context.Context, addr, hash string,
+) (chain.PendingTx, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return chain.PendingTx{}, err
+  }
+  defer conn.Close()
+  cln := rpc.NewMempoolClient(conn)
+  req := &rpc.MempoolGetReq{Hash: hash}
+  res, err := cln.MempoolGet(ctx, req)
+  if err != nil {
+    return chain.PendingTx{}, err
+  }
+  var tx chain.PendingTx
+  err = json.Unmarshal(res.Tx, &tx)
+  return tx, err
+}
+
+func mempoolGetCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "get",
+    Short: "Gets the pending transaction by the transaction hash prefix",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      hash, _ := cmd.Flags().GetString("hash")
+      tx, err := grpcMempoolGet(ctx, addr, hash)
+      if err != nil {
+        return err
+      }
+      fmt.Printf("tx  %s\n", tx.Hash())
+      fmt.Printf("%v\n", tx)
+      return nil
+    },
+  }
+  cmd.Flags().String("hash", "", "transaction hash prefix")
+  _ = cmd.MarkFlagRequired("hash")
+  return cmd
+}
+
+func grpcMempoolStats(
+  ctx context.Context, addr string,
+) (*rpc.MempoolStatsRes, []chain.PendingStats, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewMempoolClient(conn)
+  req := &rpc.MempoolStatsReq{}
+  res, err := cln.MempoolStats(ctx, req)
+  if err != nil {
+    return nil, nil, err
+  }
+  var stats []chain.PendingStats
+  err = json.Unmarshal(res.Accounts, &stats)
+  return res, stats, err
+}
+
+func mempoolStatsCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "stats",
+    Short: "Summarizes the pending transactions per account",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      res, stats, err := grpcMempoolStats(ctx, addr)
+      if err != nil {
+        return err
+      }
+      fmt.Printf("txs %d   bytes %d\n", res.Count, res.Bytes)
+      for _, acc := range stats {
+        fmt.Printf("%v\n", acc)
+      }
+      return nil
+    },
+  }
+  return cmd
+}
@@ -45,6 +45,7 @@ func nodeStartCmd(_ context.Context) *cobra.Command {
       if !bootstrap && !reAddr.MatchString(seedAddr) {
         return fmt.Errorf("expected --seed host:port, got %v", seedAddr)
       }
+      fastSync, _ := cmd.Flags().GetBool("fastsync")
       rePort := regexp.MustCompile(`\d+$`)
       port := rePort.FindString(nodeAddr)
       keyStoreDir, _ := cmd.Flags().GetString("keystore")
@@ -55,32 +56,105 @@ func nodeStartCmd(_ context.Context) *cobra.Command {
       if len(blockStoreDir) == 0 {
         blockStoreDir = ".blockstore" + port
       }
+      storeType, _ := cmd.Flags().GetString("storetype")
+      snapshot, _ := cmd.Flags().GetUint64("snapshot")
+      replay, _ := cmd.Flags().GetBool("replay")
+      archive, _ := cmd.Flags().GetBool("archive")
       name, _ := cmd.Flags().GetString("chain")
       authPass, _ := cmd.Flags().GetString("authpass")
+      if bootstrap && len(authPass) == 0 {
+        return fmt.Errorf("--bootstrap requires --authpass")
+      }
+      validators, _ := cmd.Flags().GetStringSlice("validators")
+      consensus, _ := cmd.Flags().GetString("consensus")
+      if consensus != chain.ConsensusPoA && consensus != chain.ConsensusBFT &&
+        consensus != chain.ConsensusPoW {
+        return fmt.Errorf(
+          "expected --consensus poa|bft|pow, got %v", consensus,
+        )
+      }
+      difficulty, _ := cmd.Flags().GetUint64("difficulty")
+      if difficulty < 1 || difficulty > 63 {
+        return fmt.Errorf("expected --difficulty 1..63, got %v", difficulty)
+      }
+      blockTime, _ := cmd.Flags().GetDuration("blocktime")
+      miner, _ := cmd.Flags().GetString("miner")
+      maxTxs, _ := cmd.Flags().GetUint64("maxtxs")
+      maxBytes, _ := cmd.Flags().GetUint64("maxbytes")
       ownerPass, _ := cmd.Flags().GetString("ownerpass")
       balance, _ := cmd.Flags().GetUint64("balance")
+      minFee, _ := cmd.Flags().GetUint64("minfee")
+      mempoolCap, _ := cmd.Flags().GetInt("mempool")
+      mempoolTTL, _ := cmd.Flags().GetDuration("mempoolttl")
       cfg := node.NodeCfg{
         NodeAddr: nodeAddr, Bootstrap: bootstrap, SeedAddr: seedAddr,
+        FastSync: fastSync,
         KeyStoreDir: keyStoreDir, BlockStoreDir: blockStoreDir,
-        Chain: name, AuthPass: authPass, OwnerPass: ownerPass, Balance: balance,
+        StoreType: storeType, SnapshotInterval: snapshot, Replay: replay,
+        Archive: archive,
+        Chain: name, AuthPass: authPass, Validators: validators,
+        Consensus: consensus, Difficulty: difficulty, BlockTime: blockTime,
+        Miner: miner, MaxTxs: maxTxs, MaxBytes: maxBytes,
+        OwnerPass: ownerPass, Balance: balance,
+        MinFee: minFee, MempoolCap: mempoolCap, MempoolTTL: mempoolTTL,
         Period: 5 * time.Second,
       }
       nd := node.NewNode(cfg)
       return nd.Start()
     },
   }
-  cmd.Flags().Bool("bootstrap", false, "bootstrap node and authority node")
+  cmd.Flags().Bool("bootstrap", false, "bootstrap node and first validator")
   cmd.Flags().String("seed", "", "seed address host:port")
   cmd.MarkFlagsMutuallyExclusive("bootstrap", "seed")
   cmd.MarkFlagsOneRequired("bootstrap", "seed")
+  cmd.Flags().Bool(
+    "fastsync", false, "sync the state from the latest seed node snapshot",
+  )
+  cmd.MarkFlagsMutuallyExclusive("bootstrap", "fastsync")
   cmd.Flags().String("keystore", "", "key store directory")
   cmd.Flags().String("blockstore", "", "block store directory")
+  cmd.Flags().String(
+    "storetype", chain.FileStoreType, "block store type file|kv",
+  )
+  cmd.Flags().Uint64("snapshot", 100, "state snapshot interval in blocks")
+  cmd.Flags().Bool(
+    "replay", false, "replay all blocks from genesis ignoring snapshots",
+  )
+  cmd.Flags().Bool(
+    "archive", false, "archive account states for historical queries",
+  )
+  cmd.MarkFlagsMutuallyExclusive("fastsync", "archive")
   cmd.Flags().String("chain", "blockchain", "blockchain name")
-  cmd.Flags().String("authpass", "", "authority account password")
+  cmd.Flags().String(
+    "authpass", "", "validator account password to propose blocks",
+  )
+  cmd.Flags().StringSlice(
+    "validators", nil, "genesis validators after the bootstrap validator",
+  )
+  cmd.Flags().String(
+    "consensus", chain.ConsensusPoA, "genesis consensus poa|bft|pow",
+  )
+  cmd.Flags().Uint64(
+    "difficulty", 16, "genesis proof of work difficulty in leading zero bits",
+  )
+  cmd.Flags().Duration(
+    "blocktime", 10 * time.Second, "genesis proof of work target block time",
+  )
+  cmd.Flags().String("miner", "", "miner account address to mine blocks")
+  cmd.Flags().Uint64("maxtxs", 1000, "genesis max number of txs per block")
+  cmd.Flags().Uint64(
+    "maxbytes", 1 << 20, "genesis max encoded block size in bytes",
+  )
   cmd.Flags().String("ownerpass", "", "owner account password")
   cmd.Flags().Uint64("balance", 0, "owner account balance")
-  cmd.MarkFlagsRequiredTogether("bootstrap", "authpass")
   cmd.MarkFlagsRequiredTogether("ownerpass", "balance")
+  cmd.Flags().Uint64("minfee", 0, "minimum tx fee accepted by the node")
+  cmd.Flags().Int(
+    "mempool", node.DefaultMempoolCap, "maximum number of txs in the mempool",
+  )
+  cmd.Flags().Duration(
+    "mempoolttl", 3 * time.Hour, "time to live of the txs in the mempool",
+  )
   return cmd
 }
 
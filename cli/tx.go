@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
@@ -19,14 +20,15 @@ func txCmd(ctx context.Context) *cobra.Command {
     Short: "Manages transactions on the blockchain",
   }
   cmd.AddCommand(
-    txSignCmd(ctx), txSendCmd(ctx), txSearchCmd(ctx),
+    txSignCmd(ctx), txSendCmd(ctx), txCancelCmd(ctx), txSearchCmd(ctx),
     txProveCmd(ctx), txVerifyCmd(ctx),
   )
   return cmd
 }
 
 func grpcTxSign(
-  ctx context.Context, addr, from, to string, value uint64, ownerPass string,
+  ctx context.Context, addr, from, to string, value, fee, nonce uint64,
+  expiry chain.TxExpiry, ownerPass string,
 ) ([]byte, error) {
   conn, err := grpc.NewClient(
     addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -36,7 +38,13 @@ func grpcTxSign(
   }
   defer conn.Close()
   cln := rpc.NewTxClient(conn)
-  req := &rpc.TxSignReq{From: from, To: to, Value: value, Password: ownerPass}
+  req := &rpc.TxSignReq{
+    From: from, To: to, Value: value, Fee: fee, Nonce: nonce,
+    ExpiryNumber: expiry.Number, Password: ownerPass,
+  }
+  if !expiry.Time.IsZero() {
+    req.ExpiryTime = expiry.Time.Unix()
+  }
   res, err := cln.TxSign(ctx, req)
   if err != nil {
     return nil, err
@@ -53,8 +61,18 @@ func txSignCmd(ctx context.Context) *cobra.Command {
       from, _ := cmd.Flags().GetString("from")
       to, _ := cmd.Flags().GetString("to")
       value, _ := cmd.Flags().GetUint64("value")
+      fee, _ := cmd.Flags().GetUint64("fee")
+      nonce, _ := cmd.Flags().GetUint64("nonce")
+      expBlock, _ := cmd.Flags().GetUint64("expblock")
+      expAfter, _ := cmd.Flags().GetDuration("expafter")
       ownerPass, _ := cmd.Flags().GetString("ownerpass")
-      jtx, err := grpcTxSign(ctx, addr, from, to, value, ownerPass)
+      expiry := chain.TxExpiry{Number: expBlock}
+      if expAfter > 0 {
+        expiry.Time = time.Now().Add(expAfter)
+      }
+      jtx, err := grpcTxSign(
+        ctx, addr, from, to, value, fee, nonce, expiry, ownerPass,
+      )
       if err != nil {
         return err
       }
@@ -68,6 +86,12 @@ func txSignCmd(ctx context.Context) *cobra.Command {
   _ = cmd.MarkFlagRequired("to")
   cmd.Flags().Uint64("value", 0, "transfer amount")
   _ = cmd.MarkFlagRequired("value")
+  cmd.Flags().Uint64("fee", 0, "tx fee paid to the block proposer")
+  cmd.Flags().Uint64(
+    "nonce", 0, "nonce of the pending tx to replace, next nonce by default",
+  )
+  cmd.Flags().Uint64("expblock", 0, "last block number the tx is valid in")
+  cmd.Flags().Duration("expafter", 0, "tx expires after the duration")
   cmd.Flags().String("ownerpass", "", "owner account password")
   _ = cmd.MarkFlagRequired("ownerpass")
   return cmd
@@ -110,6 +134,43 @@ func txSendCmd(ctx context.Context) *cobra.Command {
   return cmd
 }
 
+func txCancelCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "cancel",
+    Short: "Cancels the pending transaction with a zero-value self-transfer",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      from, _ := cmd.Flags().GetString("from")
+      nonce, _ := cmd.Flags().GetUint64("nonce")
+      fee, _ := cmd.Flags().GetUint64("fee")
+      ownerPass, _ := cmd.Flags().GetString("ownerpass")
+      jtx, err := grpcTxSign(
+        ctx, addr, from, from, 0, fee, nonce, chain.TxExpiry{}, ownerPass,
+      )
+      if err != nil {
+        return err
+      }
+      hash, err := grpcTxSend(ctx, addr, string(jtx))
+      if err != nil {
+        return err
+      }
+      fmt.Printf("tx %s\n", hash)
+      return nil
+    },
+  }
+  cmd.Flags().String("from", "", "sender address")
+  _ = cmd.MarkFlagRequired("from")
+  cmd.Flags().Uint64("nonce", 0, "nonce of the pending tx to cancel")
+  _ = cmd.MarkFlagRequired("nonce")
+  cmd.Flags().Uint64(
+    "fee", 0, "tx fee higher than the fee of the pending tx to cancel",
+  )
+  _ = cmd.MarkFlagRequired("fee")
+  cmd.Flags().String("ownerpass", "", "owner account password")
+  _ = cmd.MarkFlagRequired("ownerpass")
+  return cmd
+}
+
 func grpcTxSearch(
   ctx context.Context, addr, hash, from, to, account string,
 ) (func(yeild func(err error, tx chain.SearchTx) bool), func(), error) {
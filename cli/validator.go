@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func validatorCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "validator",
+    Short: "Manages the validator set of the blockchain",
+  }
+  cmd.AddCommand(
+    validatorListCmd(ctx), validatorSignCmd(ctx, "add"),
+    validatorSignCmd(ctx, "remove"), validatorSignCmd(ctx, "power"),
+    validatorApproveCmd(ctx),
+  )
+  return cmd
+}
+
+func grpcValidatorList(
+  ctx context.Context, addr string,
+) ([]chain.Validator, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewValidatorClient(conn)
+  req := &rpc.ValidatorListReq{}
+  res, err := cln.ValidatorList(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  var vals []chain.Validator
+  err = json.Unmarshal(res.Validators, &vals)
+  return vals, err
+}
+
+func validatorListCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "list",
+    Short: "Lists the active validator set with the voting power",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      vals, err := grpcValidatorList(ctx, addr)
+      if err != nil {
+        return err
+      }
+      for _, val := range vals {
+        fmt.Printf("%v\n", val)
+      }
+      return nil
+    },
+  }
+  return cmd
+}
+
+func grpcValidatorSign(
+  ctx context.Context, addr, kind, from, validator string, power, fee uint64,
+  ownerPass string,
+) ([]byte, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewValidatorClient(conn)
+  req := &rpc.ValidatorSignReq{
+    Kind: kind, From: from, Validator: validator, Power: power, Fee: fee,
+    Password: ownerPass,
+  }
+  res, err := cln.ValidatorSign(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  return res.Tx, nil
+}
+
+func validatorSignCmd(ctx context.Context, kind string) *cobra.Command {
+  short := map[string]string{
+    "add": "Signs a new tx to add the validator",
+    "remove": "Signs a new tx to remove the validator",
+    "power": "Signs a new tx to change the validator voting power",
+  }
+  cmd := &cobra.Command{
+    Use: kind,
+    Short: short[kind],
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      from, _ := cmd.Flags().GetString("from")
+      validator, _ := cmd.Flags().GetString("validator")
+      power, _ := cmd.Flags().GetUint64("power")
+      fee, _ := cmd.Flags().GetUint64("fee")
+      ownerPass, _ := cmd.Flags().GetString("ownerpass")
+      jtx, err := grpcValidatorSign(
+        ctx, addr, kind, from, validator, power, fee, ownerPass,
+      )
+      if err != nil {
+        return err
+      }
+      fmt.Printf("%s\n", jtx)
+      return nil
+    },
+  }
+  cmd.Flags().String("from", "", "sender validator address")
+  _ = cmd.MarkFlagRequired("from")
+  cmd.Flags().String("validator", "", "target validator address")
+  _ = cmd.MarkFlagRequired("validator")
+  if kind != "remove" {
+    cmd.Flags().Uint64("power", 1, "validator voting power")
+  }
+  cmd.Flags().Uint64("fee", 0, "tx fee paid to the block proposer")
+  cmd.Flags().String("ownerpass", "", "sender validator account password")
+  _ = cmd.MarkFlagRequired("ownerpass")
+  return cmd
+}
+
+func grpcValidatorApprove(
+  ctx context.Context, addr, tx, validator, ownerPass string,
+) ([]byte, error) {
+  conn, err := grpc.NewClient(
+    addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewValidatorClient(conn)
+  req := &rpc.ValidatorApproveReq{
+    Tx: []byte(tx), Validator: validator, Password: ownerPass,
+  }
+  res, err := cln.ValidatorApprove(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  return res.Tx, nil
+}
+
+func validatorApproveCmd(ctx context.Context) *cobra.Command {
+  cmd := &cobra.Command{
+    Use: "approve",
+    Short: "Approves the signed validator tx with the validator signature",
+    RunE: func(cmd *cobra.Command, _ []string) error {
+      addr, _ := cmd.Flags().GetString("node")
+      tx, _ := cmd.Flags().GetString("sigtx")
+      validator, _ := cmd.Flags().GetString("validator")
+      ownerPass, _ := cmd.Flags().GetString("ownerpass")
+      jtx, err := grpcValidatorApprove(ctx, addr, tx, validator, ownerPass)
+      if err != nil {
+        return err
+      }
+      fmt.Printf("%s\n", jtx)
+      return nil
+    },
+  }
+  cmd.Flags().String("sigtx", "", "signed encoded validator transaction")
+  _ = cmd.MarkFlagRequired("sigtx")
+  cmd.Flags().String("validator", "", "approving validator address")
+  _ = cmd.MarkFlagRequired("validator")
+  cmd.Flags().String("ownerpass", "", "approving validator account password")
+  _ = cmd.MarkFlagRequired("ownerpass")
+  return cmd
+}
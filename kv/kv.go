@@ -0,0 +1,315 @@
+package kv
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DB is an embedded append-only key-value store. Every write is a batch of
+// operations appended to the log file as a single checksummed record. The
+// in-memory key directory maps live keys to value positions in the log file.
+// The ordered key index keeps live keys sorted for the prefix and range scans
+type DB struct {
+  mtx sync.RWMutex
+  file *os.File
+  size int64
+  dropped int64
+  keys map[string]entry
+  sorted []string
+}
+
+type entry struct {
+  offset int64
+  length int64
+}
+
+type op struct {
+  key []byte
+  val []byte
+  del bool
+}
+
+type Batch struct {
+  ops []op
+}
+
+func (b *Batch) Put(key, val []byte) {
+  b.ops = append(b.ops, op{key: key, val: val})
+}
+
+func (b *Batch) Delete(key []byte) {
+  b.ops = append(b.ops, op{key: key, del: true})
+}
+
+const (
+  // crc32 of the payload and the payload length
+  headerLen = 8
+  // op flag, key length, value length
+  opHeaderLen = 9
+  opPut = byte(0)
+  opDel = byte(1)
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+func Open(path string) (*DB, error) {
+  file, err := os.OpenFile(path, os.O_CREATE | os.O_RDWR, 0600)
+  if err != nil {
+    return nil, err
+  }
+  db := &DB{file: file, keys: make(map[string]entry)}
+  err = db.load()
+  if err != nil {
+    file.Close()
+    return nil, err
+  }
+  return db, nil
+}
+
+// load replays the log file to rebuild the key directory. A torn or corrupted
+// record at the tail of the log file is truncated
+func (db *DB) load() error {
+  _, err := db.file.Seek(0, io.SeekStart)
+  if err != nil {
+    return err
+  }
+  rd := bufio.NewReader(db.file)
+  offset := int64(0)
+  header := make([]byte, headerLen)
+  for {
+    _, err := io.ReadFull(rd, header)
+    if err == io.EOF || err == io.ErrUnexpectedEOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    sum := binary.BigEndian.Uint32(header[0:4])
+    length := binary.BigEndian.Uint32(header[4:8])
+    payload := make([]byte, length)
+    _, err = io.ReadFull(rd, payload)
+    if err == io.EOF || err == io.ErrUnexpectedEOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if crc32.Checksum(payload, crcTable) != sum {
+      break
+    }
+    err = db.index(payload, offset + headerLen)
+    if err != nil {
+      break
+    }
+    offset += headerLen + int64(length)
+  }
+  info, err := db.file.Stat()
+  if err != nil {
+    return err
+  }
+  if info.Size() > offset {
+    err = db.file.Truncate(offset)
+    if err != nil {
+      return err
+    }
+    err = db.file.Sync()
+    if err != nil {
+      return err
+    }
+    db.dropped = info.Size() - offset
+  }
+  db.size = offset
+  return nil
+}
+
+// Dropped returns the number of bytes truncated from the tail of the log file
+// on open
+func (db *DB) Dropped() int64 {
+  return db.dropped
+}
+
+func (db *DB) index(payload []byte, offset int64) error {
+  keys := make(map[string]entry)
+  dels := make([]string, 0)
+  i := 0
+  for i < len(payload) {
+    if i + opHeaderLen > len(payload) {
+      return fmt.Errorf("kv: malformed record")
+    }
+    flag := payload[i]
+    klen := int(binary.BigEndian.Uint32(payload[i + 1:i + 5]))
+    vlen := int(binary.BigEndian.Uint32(payload[i + 5:i + 9]))
+    i += opHeaderLen
+    if i + klen + vlen > len(payload) {
+      return fmt.Errorf("kv: malformed record")
+    }
+    key := string(payload[i:i + klen])
+    i += klen
+    if flag == opDel {
+      delete(keys, key)
+      dels = append(dels, key)
+    } else {
+      keys[key] = entry{offset: offset + int64(i), length: int64(vlen)}
+    }
+    i += vlen
+  }
+  for _, key := range dels {
+    _, exist := db.keys[key]
+    if !exist {
+      continue
+    }
+    delete(db.keys, key)
+    i, _ := slices.BinarySearch(db.sorted, key)
+    db.sorted = slices.Delete(db.sorted, i, i + 1)
+  }
+  for key, ent := range keys {
+    _, exist := db.keys[key]
+    db.keys[key] = ent
+    if !exist {
+      i, _ := slices.BinarySearch(db.sorted, key)
+      db.sorted = slices.Insert(db.sorted, i, key)
+    }
+  }
+  return nil
+}
+
+func encodeBatch(b *Batch) []byte {
+  length := 0
+  for _, op := range b.ops {
+    length += opHeaderLen + len(op.key) + len(op.val)
+  }
+  rec := make([]byte, headerLen, headerLen + length)
+  for _, op := range b.ops {
+    opHeader := make([]byte, opHeaderLen)
+    if op.del {
+      opHeader[0] = opDel
+    }
+    binary.BigEndian.PutUint32(opHeader[1:5], uint32(len(op.key)))
+    binary.BigEndian.PutUint32(opHeader[5:9], uint32(len(op.val)))
+    rec = append(rec, opHeader...)
+    rec = append(rec, op.key...)
+    rec = append(rec, op.val...)
+  }
+  payload := rec[headerLen:]
+  binary.BigEndian.PutUint32(rec[0:4], crc32.Checksum(payload, crcTable))
+  binary.BigEndian.PutUint32(rec[4:8], uint32(len(payload)))
+  return rec
+}
+
+// Write atomically appends the batch to the log file and syncs the log file to
+// the stable storage before updating the key directory
+func (db *DB) Write(b *Batch) error {
+  if len(b.ops) == 0 {
+    return nil
+  }
+  rec := encodeBatch(b)
+  db.mtx.Lock()
+  defer db.mtx.Unlock()
+  _, err := db.file.WriteAt(rec, db.size)
+  if err != nil {
+    return err
+  }
+  err = db.file.Sync()
+  if err != nil {
+    return err
+  }
+  err = db.index(rec[headerLen:], db.size + headerLen)
+  if err != nil {
+    return err
+  }
+  db.size += int64(len(rec))
+  return nil
+}
+
+func (db *DB) Put(key, val []byte) error {
+  var b Batch
+  b.Put(key, val)
+  return db.Write(&b)
+}
+
+func (db *DB) Delete(key []byte) error {
+  var b Batch
+  b.Delete(key)
+  return db.Write(&b)
+}
+
+func (db *DB) Get(key []byte) ([]byte, bool, error) {
+  db.mtx.RLock()
+  defer db.mtx.RUnlock()
+  ent, exist := db.keys[string(key)]
+  if !exist {
+    return nil, false, nil
+  }
+  val := make([]byte, ent.length)
+  _, err := db.file.ReadAt(val, ent.offset)
+  if err != nil {
+    return nil, false, err
+  }
+  return val, true, nil
+}
+
+func (db *DB) Has(key []byte) bool {
+  db.mtx.RLock()
+  defer db.mtx.RUnlock()
+  _, exist := db.keys[string(key)]
+  return exist
+}
+
+// Keys returns the sorted list of live keys that start with the prefix
+func (db *DB) Keys(prefix []byte) []string {
+  db.mtx.RLock()
+  defer db.mtx.RUnlock()
+  i, _ := slices.BinarySearch(db.sorted, string(prefix))
+  keys := make([]string, 0)
+  for _, key := range db.sorted[i:] {
+    if !strings.HasPrefix(key, string(prefix)) {
+      break
+    }
+    keys = append(keys, key)
+  }
+  return keys
+}
+
+// Range returns the sorted list of live keys from the start key inclusive to
+// the end key exclusive
+func (db *DB) Range(start, end []byte) []string {
+  db.mtx.RLock()
+  defer db.mtx.RUnlock()
+  i, _ := slices.BinarySearch(db.sorted, string(start))
+  keys := make([]string, 0)
+  for _, key := range db.sorted[i:] {
+    if key >= string(end) {
+      break
+    }
+    keys = append(keys, key)
+  }
+  return keys
+}
+
+// Floor returns the greatest live key that starts with the prefix and is not
+// greater than the key
+func (db *DB) Floor(prefix, key []byte) (string, bool) {
+  db.mtx.RLock()
+  defer db.mtx.RUnlock()
+  i, found := slices.BinarySearch(db.sorted, string(key))
+  if found {
+    i++
+  }
+  if i == 0 || !strings.HasPrefix(db.sorted[i - 1], string(prefix)) {
+    return "", false
+  }
+  return db.sorted[i - 1], true
+}
+
+func (db *DB) Close() error {
+  db.mtx.Lock()
+  defer db.mtx.Unlock()
+  return db.file.Close()
+}
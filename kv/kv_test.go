@@ -0,0 +1,161 @@
+package kv_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/kv"
+)
+
+const kvDir = ".kvstore"
+
+func TestDBWriteReadReopen(t *testing.T) {
+  defer os.RemoveAll(kvDir)
+  err := os.MkdirAll(kvDir, 0700)
+  if err != nil {
+    t.Fatal(err)
+  }
+  path := filepath.Join(kvDir, "test.kv")
+  // Open a new key-value store
+  db, err := kv.Open(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Atomically write a batch of key-value pairs
+  var batch kv.Batch
+  batch.Put([]byte("a/1"), []byte("one"))
+  batch.Put([]byte("a/2"), []byte("two"))
+  batch.Put([]byte("b/1"), []byte("three"))
+  err = db.Write(&batch)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Overwrite and delete keys
+  err = db.Put([]byte("a/1"), []byte("uno"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = db.Delete([]byte("b/1"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  verify := func(t *testing.T, db *kv.DB) {
+    // Verify that the latest values are returned for live keys
+    for key, exp := range map[string]string{"a/1": "uno", "a/2": "two"} {
+      got, exist, err := db.Get([]byte(key))
+      if err != nil {
+        t.Fatal(err)
+      }
+      if !exist || string(got) != exp {
+        t.Errorf("invalid value %v: expected %v, got %s", key, exp, got)
+      }
+    }
+    // Verify that the deleted key does not exist
+    if db.Has([]byte("b/1")) {
+      t.Errorf("deleted key exists")
+    }
+    // Verify that the keys with a prefix are returned in order
+    got, exp := db.Keys([]byte("a/")), []string{"a/1", "a/2"}
+    if !slices.Equal(got, exp) {
+      t.Errorf("invalid keys: expected %v, got %v", exp, got)
+    }
+  }
+  t.Run("read written keys", func(t *testing.T) {
+    verify(t, db)
+  })
+  err = db.Close()
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Run("read keys after reopen", func(t *testing.T) {
+    // Re-open the key-value store to replay the log file
+    db, err := kv.Open(path)
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer db.Close()
+    verify(t, db)
+  })
+  t.Run("truncate torn tail record", func(t *testing.T) {
+    // Append a partial record to simulate a torn write
+    file, err := os.OpenFile(path, os.O_APPEND | os.O_WRONLY, 0600)
+    if err != nil {
+      t.Fatal(err)
+    }
+    _, err = file.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x00, 0x10})
+    if err != nil {
+      t.Fatal(err)
+    }
+    file.Close()
+    // Re-open the key-value store to truncate the torn tail record
+    db, err := kv.Open(path)
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer db.Close()
+    verify(t, db)
+    // Verify that the torn tail record is reported as dropped
+    if db.Dropped() != 7 {
+      t.Errorf("invalid dropped bytes: expected 7, got %v", db.Dropped())
+    }
+    // Verify that new writes after the truncated tail are readable
+    err = db.Put([]byte("a/3"), []byte("three"))
+    if err != nil {
+      t.Fatal(err)
+    }
+    got, exist, err := db.Get([]byte("a/3"))
+    if err != nil {
+      t.Fatal(err)
+    }
+    if !exist || string(got) != "three" {
+      t.Errorf("invalid value after truncated tail: %s", got)
+    }
+  })
+}
+
+func TestDBOrderedScans(t *testing.T) {
+  defer os.RemoveAll(kvDir)
+  err := os.MkdirAll(kvDir, 0700)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Open a new key-value store and write the keys out of order
+  db, err := kv.Open(filepath.Join(kvDir, "scan.kv"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer db.Close()
+  var batch kv.Batch
+  for _, key := range []string{"b/3", "a/1", "b/1", "c/1", "b/2"} {
+    batch.Put([]byte(key), []byte(key))
+  }
+  batch.Delete([]byte("b/2"))
+  err = db.Write(&batch)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the prefix scan returns only the live keys with the prefix
+  got, exp := db.Keys([]byte("b/")), []string{"b/1", "b/3"}
+  if !slices.Equal(got, exp) {
+    t.Errorf("invalid prefix keys: expected %v, got %v", exp, got)
+  }
+  // Verify that the range scan includes the start and excludes the end
+  got, exp = db.Range([]byte("a/1"), []byte("b/3")), []string{"a/1", "b/1"}
+  if !slices.Equal(got, exp) {
+    t.Errorf("invalid range keys: expected %v, got %v", exp, got)
+  }
+  // Verify that the floor key is the greatest key with the prefix at or before
+  // the key
+  cases := []struct{ key, exp string; exist bool }{
+    {"b/1", "b/1", true}, {"b/2", "b/1", true}, {"b/9", "b/3", true},
+    {"b/0", "", false},
+  }
+  for _, c := range cases {
+    got, exist := db.Floor([]byte("b/"), []byte(c.key))
+    if got != c.exp || exist != c.exist {
+      t.Errorf("invalid floor of %v: expected %v, got %v", c.key, c.exp, got)
+    }
+  }
+}
@@ -20,7 +20,9 @@ type BlockProposer struct {
   wg *sync.WaitGroup
   authority chain.Account
   state *chain.State
+  mempool *Mempool
   blkRelayer BlockRelayer
+  proposed chain.SigBlock
 }
 
 func NewBlockProposer(
@@ -37,12 +39,18 @@ func (p *BlockProposer) SetState(state *chain.State) {
   p.state = state
 }
 
+func (p *BlockProposer) SetMempool(mempool *Mempool) {
+  p.mempool = mempool
+}
+
 func randPeriod(maxPeriod time.Duration) time.Duration {
   minPeriod := maxPeriod / 2
   randSpan, _ := rand.Int(rand.Reader, big.NewInt(int64(maxPeriod)))
   return minPeriod + time.Duration(randSpan.Int64())
 }
 
+// ProposeBlocks proposes the next block only when it is the turn of the
+// validator in the current round
 func (p *BlockProposer) ProposeBlocks(maxPeriod time.Duration) {
   defer p.wg.Done()
   randPropose := time.NewTimer(randPeriod(maxPeriod))
@@ -53,8 +61,27 @@ func (p *BlockProposer) ProposeBlocks(maxPeriod time.Duration) {
       return
     case <- randPropose.C:
       randPropose.Reset(randPeriod(maxPeriod))
+      lastBlock := p.state.LastBlock()
+      round := p.state.SlotRound(time.Now())
+      proposer := chain.Proposer(
+        p.state.Validators(), lastBlock.Number + 1, round,
+      )
+      if proposer != p.authority.Address() {
+        continue
+      }
+      // The validator re-relays the block already proposed at the block number
+      // instead of signing a conflicting block in a later round
+      if p.proposed.Number == lastBlock.Number + 1 {
+        if p.blkRelayer != nil {
+          p.blkRelayer.RelayBlock(p.proposed)
+        }
+        continue
+      }
       clone := p.state.Clone()
-      blk, err := clone.CreateBlock(p.authority)
+      if p.mempool != nil {
+        clone.SetPendingTxs(p.mempool.Txs())
+      }
+      blk, err := clone.CreateBlock(p.authority, round)
       if err != nil {
         continue
       }
@@ -67,6 +94,7 @@ func (p *BlockProposer) ProposeBlocks(maxPeriod time.Duration) {
         fmt.Println(err)
         continue
       }
+      p.proposed = blk
       if p.blkRelayer != nil {
         p.blkRelayer.RelayBlock(blk)
       }
@@ -53,7 +53,7 @@ func TestBlockProposer(t *testing.T) {
   // Create and start the block relay for the bootstrap node
   bootBlkRelay := createBlockRelay(ctx, wg, bootPeerDisc)
   // Re-create the authority account from the genesis to sign blocks
-  path := filepath.Join(bootKeyStoreDir, string(bootState.Authority()))
+  path := filepath.Join(bootKeyStoreDir, string(bootState.Validators()[0]))
   auth, err := chain.ReadAccount(path, []byte(authPass))
   if err != nil {
     t.Fatal(err)
@@ -65,10 +65,16 @@ func TestBlockProposer(t *testing.T) {
     node := rpc.NewNodeSrv(bootPeerDisc, nil)
     rpc.RegisterNodeServer(grpcSrv, node)
     tx := rpc.NewTxSrv(
-      bootKeyStoreDir, bootBlockStoreDir, bootState.Pending, nil,
+      bootKeyStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      bootState.Pending, nil, 0,
     )
     rpc.RegisterTxServer(grpcSrv, tx)
-    blk := rpc.NewBlockSrv(bootBlockStoreDir, nil, bootState, bootBlkRelay)
+    blk := rpc.NewBlockSrv(
+      bootBlockStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      nil, bootState, bootBlkRelay,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Create and start the peer discovery for the new node
@@ -83,9 +89,15 @@ func TestBlockProposer(t *testing.T) {
   }
   // Start the gRPC server on the new node
   grpcStartSvr(t, nodeAddr, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, nodeState.Pending, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir, chain.KVStoreType),
+      nodeState.Pending, nil, 0,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
-    blk := rpc.NewBlockSrv(blockStoreDir, nil, nodeState, nil)
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir, chain.KVStoreType),
+      nil, nodeState, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Wait for the gRPC server of the new node to start
@@ -106,7 +118,7 @@ func TestBlockProposer(t *testing.T) {
   sendTxs(t, ctx, acc, []uint64{12, 34}, bootState.Pending, bootAddr)
   // Wait for the block proposal to propose a block and the block relay to
   // propagate the proposed block
-  time.Sleep(500 * time.Millisecond)
+  time.Sleep(time.Second)
   // Verify that the initial account balance on the confirmed state of the new
   // node and the bootstrap node are equal
   expBalance := ownerBal - 12 - 34
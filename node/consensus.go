@@ -0,0 +1,445 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+type ConsensusRelayer interface {
+  RelayConsensus(msg chain.ConsensusMsg)
+}
+
+type consensusStep uint64
+
+const (
+  stepPropose consensusStep = iota
+  stepPrevote
+  stepPrecommit
+  stepCommit
+)
+
+type consensusTimeout struct {
+  number, round uint64
+  step consensusStep
+}
+
+type voteKey struct {
+  voteType chain.VoteType
+  round uint64
+}
+
+// Consensus is the Tendermint-style propose, prevote, precommit consensus of
+// the validators. The block is committed with the precommits of more than 2/3
+// of the validators. The committed block is relayed to all nodes
+type Consensus struct {
+  ctx context.Context
+  wg *sync.WaitGroup
+  validator chain.Account
+  state *chain.State
+  csRelayer ConsensusRelayer
+  blkRelayer BlockRelayer
+  evRelayer EvidenceRelayer
+  mempool *Mempool
+  chMsg chan chain.ConsensusMsg
+  chTimeout chan consensusTimeout
+  timeout time.Duration
+  // The consensus state at the block number
+  number, round uint64
+  step consensusStep
+  proposals map[uint64]chain.SigBlock
+  votes map[voteKey]map[chain.Address]chain.SigVote
+  lockedBlock *chain.SigBlock
+  lockedRound uint64
+  validBlock *chain.SigBlock
+  proposedBlock *chain.SigBlock
+  prevoteWait, precommitWait bool
+}
+
+func NewConsensus(
+  ctx context.Context, wg *sync.WaitGroup,
+  csRelayer ConsensusRelayer, blkRelayer BlockRelayer,
+) *Consensus {
+  return &Consensus{
+    ctx: ctx, wg: wg, csRelayer: csRelayer, blkRelayer: blkRelayer,
+    chMsg: make(chan chain.ConsensusMsg, 100),
+    chTimeout: make(chan consensusTimeout, 10),
+  }
+}
+
+func (c *Consensus) SetValidator(val chain.Account) {
+  c.validator = val
+}
+
+func (c *Consensus) SetState(state *chain.State) {
+  c.state = state
+}
+
+func (c *Consensus) SetMempool(mempool *Mempool) {
+  c.mempool = mempool
+}
+
+func (c *Consensus) SetEvidenceRelayer(evRelayer EvidenceRelayer) {
+  c.evRelayer = evRelayer
+}
+
+// ReceiveConsensus drops the message when the consensus is not running or
+// lags behind
+func (c *Consensus) ReceiveConsensus(msg chain.ConsensusMsg) {
+  select {
+  case c.chMsg <- msg:
+  default:
+  }
+}
+
+func (c *Consensus) RunConsensus(timeout time.Duration) {
+  defer c.wg.Done()
+  c.timeout = timeout
+  c.newHeight(c.state.LastBlock().Number + 1)
+  tick := time.NewTicker(timeout / 2)
+  defer tick.Stop()
+  for {
+    select {
+    case <- c.ctx.Done():
+      return
+    case <- tick.C:
+      number := c.state.LastBlock().Number + 1
+      if number > c.number {
+        c.newHeight(number)
+      }
+    case msg := <- c.chMsg:
+      if c.state.LastBlock().Number + 1 > c.number {
+        c.newHeight(c.state.LastBlock().Number + 1)
+      }
+      switch {
+      case msg.Proposal != nil:
+        c.receiveProposal(*msg.Proposal)
+      case msg.Vote != nil:
+        c.receiveVote(*msg.Vote)
+      }
+    case to := <- c.chTimeout:
+      c.receiveTimeout(to)
+    }
+  }
+}
+
+func (c *Consensus) newHeight(number uint64) {
+  c.number = number
+  c.proposals = make(map[uint64]chain.SigBlock)
+  c.votes = make(map[voteKey]map[chain.Address]chain.SigVote)
+  c.lockedBlock, c.lockedRound, c.validBlock = nil, 0, nil
+  c.proposedBlock = nil
+  c.startRound(0)
+}
+
+func (c *Consensus) scheduleTimeout(step consensusStep) {
+  to := consensusTimeout{number: c.number, round: c.round, step: step}
+  time.AfterFunc(c.timeout, func() {
+    select {
+    case c.chTimeout <- to:
+    case <- c.ctx.Done():
+    }
+  })
+}
+
+func (c *Consensus) startRound(round uint64) {
+  c.round, c.step = round, stepPropose
+  c.prevoteWait, c.precommitWait = false, false
+  proposer := chain.Proposer(c.state.Validators(), c.number, round)
+  if proposer == c.validator.Address() {
+    c.propose()
+  }
+  c.scheduleTimeout(stepPropose)
+  prop, exist := c.proposals[round]
+  if exist {
+    c.prevoteProposal(prop)
+  }
+  c.checkVotes()
+}
+
+// propose re-proposes the valid block from the earlier round or the own block
+// from the earlier round, or creates a new block from the pending txs. The
+// validator never signs two different blocks at the same block number
+func (c *Consensus) propose() {
+  var blk chain.SigBlock
+  switch {
+  case c.validBlock != nil:
+    blk = *c.validBlock
+  case c.proposedBlock != nil:
+    blk = *c.proposedBlock
+  default:
+    clone := c.state.Clone()
+    if c.mempool != nil {
+      clone.SetPendingTxs(c.mempool.Txs())
+    }
+    var err error
+    blk, err = clone.CreateBlock(c.validator, c.round)
+    if err != nil {
+      return
+    }
+    c.proposedBlock = &blk
+  }
+  prop := chain.NewProposal(c.round, blk, c.validator.Address())
+  sprop, err := c.validator.SignProposal(prop)
+  if err != nil {
+    fmt.Println(err)
+    return
+  }
+  fmt.Printf("==> Block propose %v/%v\n%v", c.number, c.round, blk)
+  c.receiveProposal(sprop)
+  c.csRelayer.RelayConsensus(chain.ConsensusMsg{Proposal: &sprop})
+}
+
+// receiveProposal accepts only the proposal signed by the proposer in turn in
+// the proposal round
+func (c *Consensus) receiveProposal(prop chain.SigProposal) {
+  blk := prop.Block
+  if blk.Number != c.number {
+    return
+  }
+  err := chain.VerifyProposal(prop, c.state.Validators())
+  if err != nil {
+    fmt.Print(err)
+    return
+  }
+  for _, prev := range c.proposals {
+    if prev.Hash() != blk.Hash() {
+      reportEquivocation(c.state, c.evRelayer, prev, blk)
+    }
+  }
+  _, exist := c.proposals[prop.Round]
+  if exist {
+    return
+  }
+  clone := c.state.Clone()
+  err = clone.ApplyBlock(blk)
+  if err != nil {
+    fmt.Print(err)
+    return
+  }
+  c.proposals[prop.Round] = blk
+  if prop.Round == c.round {
+    c.prevoteProposal(blk)
+  }
+  c.checkVotes()
+}
+
+// prevoteProposal prevotes the proposed block unless the validator is locked on
+// a different block without a newer polka for the proposed block
+func (c *Consensus) prevoteProposal(blk chain.SigBlock) {
+  if c.step != stepPropose {
+    return
+  }
+  hash := blk.Hash()
+  if c.lockedBlock != nil && c.lockedBlock.Hash() != hash &&
+    !c.polka(hash, c.lockedRound) {
+    hash = chain.Hash{}
+  }
+  c.vote(chain.Prevote, hash)
+  c.step = stepPrevote
+}
+
+// polka checks whether the validators with more than 2/3 of the total voting
+// power prevoted the block in any round from the round to the current round
+func (c *Consensus) polka(hash chain.Hash, round uint64) bool {
+  quorum := chain.Quorum(chain.TotalPower(c.state.ValidatorSet()))
+  for r := round; r < c.round; r++ {
+    votes, _ := c.countVotes(chain.Prevote, r)
+    if votes[hash] >= quorum {
+      return true
+    }
+  }
+  return false
+}
+
+func (c *Consensus) vote(voteType chain.VoteType, hash chain.Hash) {
+  vote := chain.NewVote(
+    voteType, c.number, c.round, hash, c.validator.Address(),
+  )
+  svote, err := c.validator.SignVote(vote)
+  if err != nil {
+    fmt.Println(err)
+    return
+  }
+  c.addVote(svote)
+  c.csRelayer.RelayConsensus(chain.ConsensusMsg{Vote: &svote})
+}
+
+func (c *Consensus) receiveVote(vote chain.SigVote) {
+  if vote.Number != c.number {
+    return
+  }
+  if !slices.Contains(c.state.Validators(), vote.Validator) {
+    return
+  }
+  valid, err := chain.VerifyVote(vote)
+  if err != nil || !valid {
+    fmt.Printf("vote error: invalid vote signature\n%v\n", vote)
+    return
+  }
+  c.addVote(vote)
+  c.checkVotes()
+}
+
+// addVote records the first vote of the validator for the vote type in the
+// round. The conflicting vote of the same validator is ignored
+func (c *Consensus) addVote(vote chain.SigVote) {
+  key := voteKey{voteType: vote.Type, round: vote.Round}
+  votes, exist := c.votes[key]
+  if !exist {
+    votes = make(map[chain.Address]chain.SigVote)
+    c.votes[key] = votes
+  }
+  prev, exist := votes[vote.Validator]
+  if exist {
+    if prev.Block != vote.Block {
+      fmt.Printf("=== Equivocation\n%v\n%v\n", prev, vote)
+    }
+    return
+  }
+  votes[vote.Validator] = vote
+}
+
+// countVotes returns the voting power of the votes for every block and the
+// total voting power of the votes for the vote type in the round
+func (c *Consensus) countVotes(
+  voteType chain.VoteType, round uint64,
+) (map[chain.Hash]uint64, uint64) {
+  powers := make(map[chain.Address]uint64)
+  for _, val := range c.state.ValidatorSet() {
+    powers[val.Address] = val.Power
+  }
+  key := voteKey{voteType: voteType, round: round}
+  counts := make(map[chain.Hash]uint64)
+  var total uint64
+  for _, vote := range c.votes[key] {
+    counts[vote.Block] += powers[vote.Validator]
+    total += powers[vote.Validator]
+  }
+  return counts, total
+}
+
+func (c *Consensus) proposal(hash chain.Hash) (chain.SigBlock, bool) {
+  for _, blk := range c.proposals {
+    if blk.Hash() == hash {
+      return blk, true
+    }
+  }
+  return chain.SigBlock{}, false
+}
+
+func (c *Consensus) checkVotes() {
+  if c.step == stepCommit {
+    return
+  }
+  power := chain.TotalPower(c.state.ValidatorSet())
+  quorum := chain.Quorum(power)
+  var nilHash chain.Hash
+  // Commit the block precommitted by more than 2/3 of the validators in any
+  // round
+  for key := range c.votes {
+    if key.voteType != chain.Precommit {
+      continue
+    }
+    counts, _ := c.countVotes(chain.Precommit, key.round)
+    for hash, count := range counts {
+      if hash == nilHash || count < quorum {
+        continue
+      }
+      blk, exist := c.proposal(hash)
+      if exist {
+        c.commit(blk, key.round)
+        return
+      }
+    }
+  }
+  // Skip to the higher round with the votes from the validators with more than
+  // 1/3 of the total voting power
+  for key := range c.votes {
+    if key.round <= c.round {
+      continue
+    }
+    _, total := c.countVotes(key.voteType, key.round)
+    if total > power - quorum {
+      c.startRound(key.round)
+      return
+    }
+  }
+  switch c.step {
+  case stepPrevote:
+    counts, total := c.countVotes(chain.Prevote, c.round)
+    for hash, count := range counts {
+      if hash == nilHash || count < quorum {
+        continue
+      }
+      blk, exist := c.proposal(hash)
+      if exist {
+        // Lock on the block with the polka
+        c.lockedBlock, c.lockedRound, c.validBlock = &blk, c.round, &blk
+        c.vote(chain.Precommit, hash)
+        c.step = stepPrecommit
+        c.checkVotes()
+        return
+      }
+    }
+    if counts[nilHash] >= quorum {
+      c.vote(chain.Precommit, nilHash)
+      c.step = stepPrecommit
+      c.checkVotes()
+      return
+    }
+    if total >= quorum && !c.prevoteWait {
+      c.prevoteWait = true
+      c.scheduleTimeout(stepPrevote)
+    }
+  case stepPrecommit:
+    _, total := c.countVotes(chain.Precommit, c.round)
+    if total >= quorum && !c.precommitWait {
+      c.precommitWait = true
+      c.scheduleTimeout(stepPrecommit)
+    }
+  }
+}
+
+func (c *Consensus) receiveTimeout(to consensusTimeout) {
+  if to.number != c.number || to.round != c.round || c.step == stepCommit {
+    return
+  }
+  switch {
+  case to.step == stepPropose && c.step == stepPropose:
+    c.vote(chain.Prevote, chain.Hash{})
+    c.step = stepPrevote
+    c.checkVotes()
+  case to.step == stepPrevote && c.step == stepPrevote:
+    c.vote(chain.Precommit, chain.Hash{})
+    c.step = stepPrecommit
+    c.checkVotes()
+  case to.step == stepPrecommit:
+    c.startRound(c.round + 1)
+  }
+}
+
+// commit attaches the precommits to the block and relays the committed block
+// to all nodes including the validator itself
+func (c *Consensus) commit(blk chain.SigBlock, round uint64) {
+  hash := blk.Hash()
+  key := voteKey{voteType: chain.Precommit, round: round}
+  precommits := make([]chain.SigVote, 0, len(c.votes[key]))
+  for _, vote := range c.votes[key] {
+    if vote.Block == hash {
+      precommits = append(precommits, vote)
+    }
+  }
+  slices.SortFunc(precommits, func(a, b chain.SigVote) int {
+    return strings.Compare(string(a.Validator), string(b.Validator))
+  })
+  blk.Commit = &chain.Commit{Round: round, Precommits: precommits}
+  c.step = stepCommit
+  fmt.Printf("==> Block commit %v/%v\n%v", c.number, round, blk)
+  c.blkRelayer.RelayBlock(blk)
+}
@@ -0,0 +1,162 @@
+package node_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"github.com/volodymyrprokopyuk/go-blockchain/node"
+)
+
+// memRelay delivers the consensus messages to all running validators and
+// applies the committed blocks to the states of all validators
+type memRelay struct {
+  mtx sync.Mutex
+  consensuses []*node.Consensus
+  states []*chain.State
+}
+
+func (r *memRelay) RelayConsensus(msg chain.ConsensusMsg) {
+  for _, cs := range r.consensuses {
+    cs.ReceiveConsensus(msg)
+  }
+}
+
+func (r *memRelay) RelayBlock(blk chain.SigBlock) {
+  r.mtx.Lock()
+  defer r.mtx.Unlock()
+  for _, state := range r.states {
+    if state.LastBlock().Number >= blk.Number {
+      continue
+    }
+    err := chain.VerifyCommit(blk, state.ValidatorSet())
+    if err != nil {
+      panic(err)
+    }
+    err = state.ApplyBlockToState(blk)
+    if err != nil {
+      panic(err)
+    }
+  }
+}
+
+func createBFTGenesis(vals []chain.Account, acc chain.Account) (
+  chain.SigGenesis, error,
+) {
+  others := make([]chain.Address, 0, len(vals) - 1)
+  for _, val := range vals[1:] {
+    others = append(others, val.Address())
+  }
+  gen := chain.NewGenesis(
+    chainName, vals[0].Address(), acc.Address(), ownerBalance, others...,
+  )
+  gen.Consensus = chain.ConsensusBFT
+  return vals[0].SignGen(gen)
+}
+
+func TestConsensus(t *testing.T) {
+  cases := []struct{
+    name string
+    offline int
+  }{
+    {"all validators online", -1},
+    {"first proposer offline", 0},
+  }
+  for _, c := range cases {
+    t.Run(c.name, func(t *testing.T) {
+      ctx, cancel := context.WithCancel(context.Background())
+      defer cancel()
+      wg := new(sync.WaitGroup)
+      defer wg.Wait()
+      defer cancel()
+      // Create four validators and the initial owner account
+      vals := make([]chain.Account, 4)
+      for i := range vals {
+        val, err := chain.NewAccount()
+        if err != nil {
+          t.Fatal(err)
+        }
+        vals[i] = val
+      }
+      acc, err := chain.NewAccount()
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Create the BFT genesis with the ordered validator set
+      gen, err := createBFTGenesis(vals, acc)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Sign a tx and apply it to the pending state of every validator
+      tx := chain.NewTx(chainName, acc.Address(), chain.Address("to"), 12, 1)
+      stx, err := acc.SignTx(tx)
+      if err != nil {
+        t.Fatal(err)
+      }
+      relay := &memRelay{}
+      for range vals {
+        state := chain.NewState(gen)
+        err = state.Pending.ApplyTx(stx)
+        if err != nil {
+          t.Fatal(err)
+        }
+        relay.states = append(relay.states, state)
+      }
+      // Create the consensus for every online validator
+      for i, val := range vals {
+        if i == c.offline {
+          continue
+        }
+        cs := node.NewConsensus(ctx, wg, relay, relay)
+        cs.SetValidator(val)
+        cs.SetState(relay.states[i])
+        relay.consensuses = append(relay.consensuses, cs)
+      }
+      // Start the consensus on all online validators
+      for _, cs := range relay.consensuses {
+        wg.Add(1)
+        go cs.RunConsensus(200 * time.Millisecond)
+      }
+      // Wait for the block to be committed
+      deadline := time.Now().Add(5 * time.Second)
+      for time.Now().Before(deadline) {
+        relay.mtx.Lock()
+        number := relay.states[1].LastBlock().Number
+        relay.mtx.Unlock()
+        if number == 1 {
+          break
+        }
+        time.Sleep(50 * time.Millisecond)
+      }
+      // Verify that the block is committed with the precommits of more than
+      // 2/3 of the validators
+      relay.mtx.Lock()
+      defer relay.mtx.Unlock()
+      blk := relay.states[1].LastBlock()
+      if blk.Number != 1 {
+        t.Fatalf("block is not committed")
+      }
+      err = chain.VerifyCommit(blk, relay.states[1].ValidatorSet())
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that the block without the commit is rejected
+      blk.Commit = nil
+      err = chain.VerifyCommit(blk, relay.states[1].ValidatorSet())
+      if err == nil {
+        t.Errorf("block without commit verified")
+      }
+      // Verify that the committed block is proposed by the first validator or
+      // by the next validator after the missed slot
+      expRound := uint64(0)
+      if c.offline == 0 {
+        expRound = 1
+      }
+      if blk.Round != expRound {
+        t.Errorf("invalid round: expected %v, got %v", expRound, blk.Round)
+      }
+    })
+  }
+}
@@ -90,7 +90,7 @@ func TestEventStream(t *testing.T) {
   // Create and start the block relay for the bootstrap node
   bootBlkRelay := createBlockRelay(ctx, wg, bootPeerDisc)
   // Re-create the authority account from the genesis to sign blocks
-  path := filepath.Join(bootKeyStoreDir, string(bootState.Authority()))
+  path := filepath.Join(bootKeyStoreDir, string(bootState.Validators()[0]))
   auth, err := chain.ReadAccount(path, []byte(authPass))
   if err != nil {
     t.Fatal(err)
@@ -104,10 +104,16 @@ func TestEventStream(t *testing.T) {
     node := rpc.NewNodeSrv(bootPeerDisc, evStream)
     rpc.RegisterNodeServer(grpcSrv, node)
     tx := rpc.NewTxSrv(
-      bootKeyStoreDir, bootBlockStoreDir, bootState.Pending, nil,
+      bootKeyStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      bootState.Pending, nil, 0,
     )
     rpc.RegisterTxServer(grpcSrv, tx)
-    blk := rpc.NewBlockSrv(bootBlockStoreDir, evStream, bootState, bootBlkRelay)
+    blk := rpc.NewBlockSrv(
+      bootBlockStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      evStream, bootState, bootBlkRelay,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Wait for the gRPC server of the bootstrap node to start
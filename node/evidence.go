@@ -0,0 +1,34 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+type EvidenceRelayer interface {
+  RelayEvidence(ev chain.Evidence)
+}
+
+// reportEquivocation applies the evidence of two conflicting blocks signed by
+// the same validator to the pending state and relays the evidence. The
+// evidence is included in the next proposed block
+func reportEquivocation(
+  state *chain.State, evRelayer EvidenceRelayer, blk1, blk2 chain.SigBlock,
+) {
+  ev := chain.NewEvidence(blk1, blk2)
+  _, err := chain.VerifyEvidence(ev)
+  if err != nil {
+    // The blocks of different validators are not an equivocation
+    return
+  }
+  err = state.Pending.ApplyEvidence(ev)
+  if err != nil {
+    fmt.Print(err)
+    return
+  }
+  fmt.Printf("=== Equivocation\n%v\n", ev)
+  if evRelayer != nil {
+    evRelayer.RelayEvidence(ev)
+  }
+}
@@ -0,0 +1,118 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+// The mempool journal in the block store directory keeps the txs accepted by
+// the mempool one JSON encoded tx per line, so the pending txs survive the
+// node restart
+const mempoolJournal = "mempool.journal"
+
+// readJournal reads the txs from the mempool journal. The incomplete last line
+// of the interrupted write is skipped
+func readJournal(path string) ([]chain.SigTx, error) {
+  file, err := os.Open(path)
+  if os.IsNotExist(err) {
+    return nil, nil
+  }
+  if err != nil {
+    return nil, err
+  }
+  defer file.Close()
+  var txs []chain.SigTx
+  sc := bufio.NewScanner(file)
+  for sc.Scan() {
+    var tx chain.SigTx
+    err := json.Unmarshal(sc.Bytes(), &tx)
+    if err != nil {
+      fmt.Println(err)
+      continue
+    }
+    txs = append(txs, tx)
+  }
+  return txs, sc.Err()
+}
+
+// LoadJournal re-applies the txs of the mempool journal to the mempool, which
+// re-validates the txs against the synced state, and opens the journal for
+// the newly accepted txs. The pending txs are returned to be re-relayed
+func (m *Mempool) LoadJournal(dir string) ([]chain.SigTx, error) {
+  path := filepath.Join(dir, mempoolJournal)
+  txs, err := readJournal(path)
+  if err != nil {
+    return nil, err
+  }
+  for _, tx := range txs {
+    err := m.ApplyTx(tx)
+    if err != nil {
+      fmt.Print(err)
+    }
+  }
+  m.mtx.Lock()
+  defer m.mtx.Unlock()
+  m.journalPath = path
+  err = m.compactJournal()
+  if err != nil {
+    return nil, err
+  }
+  pending := m.pendingTxs()
+  fmt.Printf("=== Mempool journal: %v pending txs\n", len(pending))
+  return pending, nil
+}
+
+// journalTx appends the accepted tx to the mempool journal
+func (m *Mempool) journalTx(tx chain.SigTx) {
+  if m.journal == nil {
+    return
+  }
+  jtx, err := json.Marshal(tx)
+  if err != nil {
+    fmt.Println(err)
+    return
+  }
+  _, err = m.journal.Write(append(jtx, '\n'))
+  if err != nil {
+    fmt.Println(err)
+  }
+}
+
+// compactJournal rewrites the mempool journal with the pending txs dropping
+// the confirmed, replaced, evicted, and expired txs
+func (m *Mempool) compactJournal() error {
+  if len(m.journalPath) == 0 {
+    return nil
+  }
+  m.closeJournal()
+  tmp := m.journalPath + ".tmp"
+  file, err := os.OpenFile(tmp, os.O_CREATE | os.O_TRUNC | os.O_WRONLY, 0600)
+  if err != nil {
+    return err
+  }
+  m.journal = file
+  for _, tx := range m.pendingTxs() {
+    m.journalTx(tx)
+  }
+  err = file.Sync()
+  if err != nil {
+    return err
+  }
+  err = os.Rename(tmp, m.journalPath)
+  if err != nil {
+    return err
+  }
+  return nil
+}
+
+func (m *Mempool) closeJournal() {
+  if m.journal != nil {
+    m.journal.Close()
+    m.journal = nil
+  }
+}
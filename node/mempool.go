@@ -0,0 +1,478 @@
+package node
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+// DefaultMempoolCap is the capacity of the mempool without an explicit
+// capacity
+const DefaultMempoolCap = 10000
+
+// Mempool keeps the pending txs of every account in the ready queue and in the
+// future queue. The ready txs have consecutive nonces right after the
+// confirmed nonce of the account and are applied to the pending state in the
+// nonce order. The future txs have a nonce gap and wait in the future queue
+// until the gap is filled. When the mempool is full, the tx with the lowest
+// fee per byte at the tail of the account queue is evicted. The pending tx is
+// replaced by the tx of the same account with the same nonce and a higher fee.
+// The expired txs and the txs older than the TTL are purged. The accepted txs
+// are journaled to disk to survive the node restart
+type Mempool struct {
+  ctx context.Context
+  wg *sync.WaitGroup
+  mtx sync.Mutex
+  state *chain.State
+  capacity int
+  ttl time.Duration
+  ready map[chain.Address][]chain.SigTx
+  future map[chain.Address]map[uint64]chain.SigTx
+  eventPub chain.EventPublisher
+  journal *os.File
+  journalPath string
+}
+
+func NewMempool(
+  ctx context.Context, wg *sync.WaitGroup, capacity int, ttl time.Duration,
+) *Mempool {
+  if capacity <= 0 {
+    capacity = DefaultMempoolCap
+  }
+  return &Mempool{
+    ctx: ctx, wg: wg, capacity: capacity, ttl: ttl,
+    ready: make(map[chain.Address][]chain.SigTx),
+    future: make(map[chain.Address]map[uint64]chain.SigTx),
+  }
+}
+
+func (m *Mempool) SetState(state *chain.State) {
+  m.state = state
+}
+
+func (m *Mempool) SetEventPublisher(eventPub chain.EventPublisher) {
+  m.eventPub = eventPub
+}
+
+func (m *Mempool) publishTx(action string, tx chain.SigTx) {
+  if m.eventPub == nil {
+    return
+  }
+  jtx, _ := json.Marshal(tx)
+  event := chain.NewEvent(chain.EvTx, action, jtx)
+  m.eventPub.PublishEvent(event)
+}
+
+func (m *Mempool) Chain() string {
+  return m.state.Chain()
+}
+
+// Nonce returns the nonce of the last ready tx of the account
+func (m *Mempool) Nonce(acc chain.Address) uint64 {
+  return m.state.Pending.Nonce(acc)
+}
+
+// Len returns the number of the ready and the future txs
+func (m *Mempool) Len() int {
+  m.mtx.Lock()
+  defer m.mtx.Unlock()
+  return m.len()
+}
+
+func (m *Mempool) len() int {
+  size := 0
+  for _, txs := range m.ready {
+    size += len(txs)
+  }
+  for _, txs := range m.future {
+    size += len(txs)
+  }
+  return size
+}
+
+// Txs returns the ready txs in the nonce order of every account
+func (m *Mempool) Txs() []chain.SigTx {
+  m.mtx.Lock()
+  defer m.mtx.Unlock()
+  return m.readyTxs()
+}
+
+// ApplyTx applies the tx with the next nonce of the account to the pending
+// state and promotes the future txs of the account. The tx with a nonce gap is
+// kept in the future queue
+func (m *Mempool) ApplyTx(tx chain.SigTx) error {
+  m.mtx.Lock()
+  defer m.mtx.Unlock()
+  if m.stale(tx, m.state.LastBlock().Number + 1, time.Now()) {
+    return fmt.Errorf("tx error: expired transaction\n%v\n", tx)
+  }
+  next := m.state.Pending.Nonce(tx.From) + 1
+  switch {
+  case tx.Nonce < next:
+    return m.replaceReady(tx)
+  case tx.Nonce > next:
+    valid, err := chain.VerifyTx(tx)
+    if err != nil {
+      return err
+    }
+    if !valid {
+      return fmt.Errorf("tx error: invalid transaction signature\n%v\n", tx)
+    }
+    if tx.Chain != m.state.Chain() {
+      return fmt.Errorf("tx error: invalid transaction chain\n%v\n", tx)
+    }
+    old, exist := m.future[tx.From][tx.Nonce]
+    if exist {
+      if tx.Fee <= old.Fee {
+        return fmt.Errorf("tx error: replacement fee too low\n%v\n", tx)
+      }
+      m.future[tx.From][tx.Nonce] = tx
+      m.journalTx(tx)
+      m.replaced(old, tx)
+      return nil
+    }
+    err = m.makeRoom(tx)
+    if err != nil {
+      return err
+    }
+    if m.future[tx.From] == nil {
+      m.future[tx.From] = make(map[uint64]chain.SigTx)
+    }
+    m.future[tx.From][tx.Nonce] = tx
+    m.journalTx(tx)
+    fmt.Printf("=== Tx queued\n%v\n", tx)
+    return nil
+  default:
+    err := m.makeRoom(tx)
+    if err != nil {
+      return err
+    }
+    err = m.state.Pending.ApplyTx(tx)
+    if err != nil {
+      return err
+    }
+    m.ready[tx.From] = append(m.ready[tx.From], tx)
+    m.journalTx(tx)
+    m.promote(tx.From)
+    return nil
+  }
+}
+
+// replaceReady replaces the ready tx of the account with the same nonce by the
+// tx with a higher fee. The replacement is rejected, when the replacement is
+// not valid on the pending state before the replaced tx
+func (m *Mempool) replaceReady(tx chain.SigTx) error {
+  txs := m.ready[tx.From]
+  i := slices.IndexFunc(txs, func(t chain.SigTx) bool {
+    return t.Nonce == tx.Nonce
+  })
+  if i == -1 {
+    return fmt.Errorf("tx error: nonce already used\n%v\n", tx)
+  }
+  old := txs[i]
+  if tx.Fee <= old.Fee {
+    return fmt.Errorf("tx error: replacement fee too low\n%v\n", tx)
+  }
+  txs[i] = tx
+  rejected := m.state.ResetPending(m.readyTxs())
+  if slices.ContainsFunc(rejected, func(t chain.SigTx) bool {
+    return t.Hash() == tx.Hash()
+  }) {
+    txs[i] = old
+    m.resetPending()
+    return fmt.Errorf("tx error: invalid replacement\n%v\n", tx)
+  }
+  // The next txs of the account may no longer be valid after the replacement
+  m.resetPending()
+  m.promote(tx.From)
+  m.journalTx(tx)
+  m.replaced(old, tx)
+  return nil
+}
+
+func (m *Mempool) replaced(old, tx chain.SigTx) {
+  fmt.Printf("=== Tx replaced\n%v\n%v\n", old, tx)
+  m.publishTx("replaced", old)
+}
+
+// promote moves the future txs of the account that follow the last ready tx
+// to the ready queue. The invalid future tx is dropped
+func (m *Mempool) promote(acc chain.Address) {
+  for {
+    next := m.state.Pending.Nonce(acc) + 1
+    tx, exist := m.future[acc][next]
+    if !exist {
+      break
+    }
+    delete(m.future[acc], next)
+    err := m.state.Pending.ApplyTx(tx)
+    if err != nil {
+      fmt.Print(err)
+      break
+    }
+    m.ready[acc] = append(m.ready[acc], tx)
+  }
+  // The future txs at or below the pending nonce are superseded
+  for nonce := range m.future[acc] {
+    if nonce <= m.state.Pending.Nonce(acc) {
+      delete(m.future[acc], nonce)
+    }
+  }
+  if len(m.future[acc]) == 0 {
+    delete(m.future, acc)
+  }
+}
+
+// tail returns the last tx in the nonce order of the account. Only the tail
+// tx is evicted to keep the nonce order of the ready txs
+func (m *Mempool) tail(acc chain.Address) (chain.SigTx, bool) {
+  if len(m.future[acc]) > 0 {
+    nonce := slices.Max(slices.Collect(maps.Keys(m.future[acc])))
+    return m.future[acc][nonce], false
+  }
+  txs := m.ready[acc]
+  return txs[len(txs) - 1], true
+}
+
+// lowest returns the tail tx with the lowest fee per byte across accounts. The
+// ready tail tx of the excluded account is not evicted, as the new tx of the
+// account follows the ready tail tx
+func (m *Mempool) lowest(exclude chain.Address) (chain.SigTx, bool, bool) {
+  var evict chain.SigTx
+  var ready, found bool
+  accs := slices.Collect(maps.Keys(m.ready))
+  for acc := range m.future {
+    accs = append(accs, acc)
+  }
+  for _, acc := range accs {
+    candidate, isReady := m.tail(acc)
+    if isReady && acc == exclude {
+      continue
+    }
+    if !found || chain.FeeRateCmp(candidate, evict) < 0 {
+      evict, ready, found = candidate, isReady, true
+    }
+  }
+  return evict, ready, found
+}
+
+// evict removes the tail tx from the ready queue or the future queue. The
+// pending state is reset separately after the ready tx is evicted
+func (m *Mempool) evict(tx chain.SigTx, ready bool) {
+  if ready {
+    txs := m.ready[tx.From]
+    m.ready[tx.From] = txs[:len(txs) - 1]
+    if len(m.ready[tx.From]) == 0 {
+      delete(m.ready, tx.From)
+    }
+  } else {
+    delete(m.future[tx.From], tx.Nonce)
+    if len(m.future[tx.From]) == 0 {
+      delete(m.future, tx.From)
+    }
+  }
+  fmt.Printf("=== Tx evicted\n%v\n", tx)
+}
+
+// makeRoom evicts the tail tx with the lowest fee per byte, when the mempool is
+// full. The new tx is rejected, when the new tx does not pay more per byte
+// than the evicted tx
+func (m *Mempool) makeRoom(tx chain.SigTx) error {
+  if m.len() < m.capacity {
+    return nil
+  }
+  evict, ready, found := m.lowest(tx.From)
+  if !found || chain.FeeRateCmp(tx, evict) <= 0 {
+    return fmt.Errorf("tx error: mempool is full\n%v\n", tx)
+  }
+  m.evict(evict, ready)
+  if ready {
+    m.resetPending()
+  }
+  return nil
+}
+
+// trim evicts the tail txs with the lowest fee per byte until the mempool
+// fits the capacity
+func (m *Mempool) trim() {
+  resetPending := false
+  for m.len() > m.capacity {
+    evict, ready, _ := m.lowest("")
+    m.evict(evict, ready)
+    resetPending = resetPending || ready
+  }
+  if resetPending {
+    m.resetPending()
+  }
+}
+
+// readyTxs returns the ready txs in the nonce order of every account
+func (m *Mempool) readyTxs() []chain.SigTx {
+  var txs []chain.SigTx
+  for _, acc := range slices.Sorted(maps.Keys(m.ready)) {
+    txs = append(txs, m.ready[acc]...)
+  }
+  return txs
+}
+
+// futureTxs returns the future txs in the nonce order of every account
+func (m *Mempool) futureTxs() []chain.SigTx {
+  var txs []chain.SigTx
+  for _, acc := range slices.Sorted(maps.Keys(m.future)) {
+    for _, nonce := range slices.Sorted(maps.Keys(m.future[acc])) {
+      txs = append(txs, m.future[acc][nonce])
+    }
+  }
+  return txs
+}
+
+// pendingTxs returns the ready txs followed by the future txs
+func (m *Mempool) pendingTxs() []chain.SigTx {
+  return append(m.readyTxs(), m.futureTxs()...)
+}
+
+// PendingTxs returns the ready txs followed by the future txs marking the
+// ready txs
+func (m *Mempool) PendingTxs() []chain.PendingTx {
+  m.mtx.Lock()
+  defer m.mtx.Unlock()
+  var txs []chain.PendingTx
+  for _, tx := range m.readyTxs() {
+    txs = append(txs, chain.PendingTx{SigTx: tx, Ready: true})
+  }
+  for _, tx := range m.futureTxs() {
+    txs = append(txs, chain.PendingTx{SigTx: tx})
+  }
+  return txs
+}
+
+// resetPending re-applies the ready txs to the pending state reset to the
+// confirmed state. The rejected tx with a nonce gap returns to the future
+// queue, the other rejected txs are dropped
+func (m *Mempool) resetPending() {
+  rejected := m.state.ResetPending(m.readyTxs())
+  for _, tx := range rejected {
+    txs := m.ready[tx.From]
+    i := slices.IndexFunc(txs, func(t chain.SigTx) bool {
+      return t.Hash() == tx.Hash()
+    })
+    if i != -1 {
+      m.ready[tx.From] = slices.Delete(txs, i, i + 1)
+    }
+    if tx.Nonce > m.state.Pending.Nonce(tx.From) + 1 {
+      if m.future[tx.From] == nil {
+        m.future[tx.From] = make(map[uint64]chain.SigTx)
+      }
+      m.future[tx.From][tx.Nonce] = tx
+    }
+  }
+  for acc, txs := range m.ready {
+    if len(txs) == 0 {
+      delete(m.ready, acc)
+    }
+  }
+}
+
+// Revalidate re-validates the pending txs after the state change. The txs
+// confirmed in the applied blocks are dropped, the txs of the reverted blocks
+// return to the mempool, the future txs are promoted, and the txs over the
+// capacity are evicted
+func (m *Mempool) Revalidate(reverted []chain.SigBlock) {
+  m.mtx.Lock()
+  defer m.mtx.Unlock()
+  for _, blk := range reverted {
+    for _, tx := range blk.Txs {
+      hash := tx.Hash()
+      exist := slices.ContainsFunc(m.ready[tx.From], func(t chain.SigTx) bool {
+        return t.Hash() == hash
+      })
+      if !exist {
+        m.ready[tx.From] = append(m.ready[tx.From], tx)
+      }
+    }
+  }
+  for _, txs := range m.ready {
+    slices.SortFunc(txs, func(a, b chain.SigTx) int {
+      return cmp.Compare(a.Nonce, b.Nonce)
+    })
+  }
+  m.purge()
+  for _, acc := range slices.Collect(maps.Keys(m.future)) {
+    m.promote(acc)
+  }
+  m.trim()
+}
+
+// stale returns true, when the tx is expired in the next block or the tx is
+// older than the TTL
+func (m *Mempool) stale(tx chain.SigTx, number uint64, now time.Time) bool {
+  return tx.Expired(number, now) ||
+    m.ttl > 0 && now.Sub(tx.Time) > m.ttl
+}
+
+// purge removes the stale txs from the ready queue and the future queue, and
+// re-applies the remaining ready txs to the pending state. The ready txs after
+// the removed ready tx of the account return to the future queue. The journal
+// is compacted to the remaining txs
+func (m *Mempool) purge() {
+  number, now := m.state.LastBlock().Number + 1, time.Now()
+  var expired []chain.SigTx
+  for acc, txs := range m.ready {
+    m.ready[acc] = slices.DeleteFunc(txs, func(tx chain.SigTx) bool {
+      stale := m.stale(tx, number, now)
+      if stale {
+        expired = append(expired, tx)
+      }
+      return stale
+    })
+  }
+  for acc, txs := range m.future {
+    for nonce, tx := range txs {
+      if m.stale(tx, number, now) {
+        expired = append(expired, tx)
+        delete(txs, nonce)
+      }
+    }
+    if len(txs) == 0 {
+      delete(m.future, acc)
+    }
+  }
+  m.resetPending()
+  err := m.compactJournal()
+  if err != nil {
+    fmt.Println(err)
+  }
+  for _, tx := range expired {
+    fmt.Printf("=== Tx expired\n%v\n", tx)
+    m.publishTx("expired", tx)
+  }
+}
+
+// PurgeTxs periodically purges the expired txs and the txs older than the TTL.
+// The journal is closed on the node shutdown
+func (m *Mempool) PurgeTxs(period time.Duration) {
+  defer m.wg.Done()
+  tick := time.NewTicker(period)
+  defer tick.Stop()
+  for {
+    select {
+    case <- m.ctx.Done():
+      m.mtx.Lock()
+      m.closeJournal()
+      m.mtx.Unlock()
+      return
+    case <- tick.C:
+      m.mtx.Lock()
+      m.purge()
+      m.mtx.Unlock()
+    }
+  }
+}
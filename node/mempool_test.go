@@ -0,0 +1,310 @@
+package node_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"github.com/volodymyrprokopyuk/go-blockchain/node"
+)
+
+func signTx(
+  acc chain.Account, value, fee, nonce uint64, expiry *chain.TxExpiry,
+) (chain.SigTx, error) {
+  tx := chain.NewTx(
+    chainName, acc.Address(), chain.Address("to"), value, nonce,
+  )
+  tx.Fee = fee
+  tx.Expiry = expiry
+  return acc.SignTx(tx)
+}
+
+type eventRecorder struct {
+  events []chain.Event
+}
+
+func (r *eventRecorder) PublishEvent(event chain.Event) {
+  r.events = append(r.events, event)
+}
+
+func TestMempool(t *testing.T) {
+  defer os.RemoveAll(bootKeyStoreDir)
+  defer os.RemoveAll(bootBlockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  wg := new(sync.WaitGroup)
+  // Initialize the state on the bootstrap node with the mempool of three txs
+  bootPeerDisc := createPeerDiscovery(ctx, wg, true, false)
+  nodeCfg := node.NodeCfg{
+    NodeAddr: bootAddr, Bootstrap: true,
+    KeyStoreDir: bootKeyStoreDir, BlockStoreDir: bootBlockStoreDir,
+    Chain: chainName, AuthPass: authPass,
+    OwnerPass: ownerPass, Balance: ownerBalance,
+  }
+  stateSync := node.NewStateSync(ctx, nodeCfg, bootPeerDisc)
+  mempool := node.NewMempool(ctx, wg, 3, 0)
+  evRec := &eventRecorder{}
+  mempool.SetEventPublisher(evRec)
+  stateSync.SetMempool(mempool)
+  state, err := stateSync.SyncState()
+  if err != nil {
+    t.Fatal(err)
+  }
+  mempool.SetState(state)
+  // Re-create the authority account and the initial owner account
+  gen, err := chain.ReadGenesis(bootBlockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  path := filepath.Join(bootKeyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  path = filepath.Join(bootKeyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  apply := func(acc chain.Account, value, fee, nonce uint64) error {
+    stx, err := signTx(acc, value, fee, nonce, nil)
+    if err != nil {
+      t.Fatal(err)
+    }
+    return mempool.ApplyTx(stx)
+  }
+  t.Run("future tx promoted", func(t *testing.T) {
+    // Apply the tx with the nonce gap
+    err := apply(acc, 12, 1, 2)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the tx with the nonce gap is queued, but not ready
+    if mempool.Len() != 1 || len(mempool.Txs()) != 0 {
+      t.Errorf("invalid ready txs: expected 0, got %v", len(mempool.Txs()))
+    }
+    // Apply the tx that fills the nonce gap
+    err = apply(acc, 34, 1, 1)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the queued tx is promoted to the ready txs
+    if len(mempool.Txs()) != 2 {
+      t.Errorf("invalid ready txs: expected 2, got %v", len(mempool.Txs()))
+    }
+    if mempool.Nonce(acc.Address()) != 2 {
+      t.Errorf(
+        "invalid nonce: expected 2, got %v", mempool.Nonce(acc.Address()),
+      )
+    }
+  })
+  t.Run("lowest fee tx evicted", func(t *testing.T) {
+    // Fill the mempool with the future tx of another account without fee
+    acc2, err := chain.NewAccount()
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = apply(acc2, 1, 0, 5)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the tx that does not pay more than the lowest fee tx is
+    // rejected by the full mempool
+    err = apply(acc, 5, 0, 3)
+    if err == nil {
+      t.Errorf("expected mempool full error, got none")
+    }
+    // Verify that the tx with the higher fee evicts the lowest fee tx
+    err = apply(acc, 5, 5, 3)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if mempool.Len() != 3 || len(mempool.Txs()) != 3 {
+      t.Errorf("invalid ready txs: expected 3, got %v", len(mempool.Txs()))
+    }
+  })
+  t.Run("revalidation after block", func(t *testing.T) {
+    // Create and apply the block from the ready txs
+    blk, err := state.Clone().CreateBlock(auth, 0)
+    if err != nil {
+      t.Fatal(err)
+    }
+    _, _, err = stateSync.ApplyForkBlock(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the confirmed txs are removed from the mempool
+    if mempool.Len() != 0 {
+      t.Errorf("invalid mempool txs: expected 0, got %v", mempool.Len())
+    }
+    // Verify that the already confirmed tx is rejected and the next tx is
+    // accepted
+    err = apply(acc, 7, 1, 3)
+    if err == nil {
+      t.Errorf("expected nonce already used error, got none")
+    }
+    err = apply(acc, 7, 1, 4)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if mempool.Nonce(acc.Address()) != 4 {
+      t.Errorf(
+        "invalid nonce: expected 4, got %v", mempool.Nonce(acc.Address()),
+      )
+    }
+  })
+  var acc2 chain.Account
+  t.Run("own ready tail not evicted", func(t *testing.T) {
+    // Fill the mempool with the future txs of another account with the fee
+    // higher than the fee of the ready tx of the sender
+    acc2, err = chain.NewAccount()
+    if err != nil {
+      t.Fatal(err)
+    }
+    for _, nonce := range []uint64{5, 6} {
+      err = apply(acc2, 1, 2, nonce)
+      if err != nil {
+        t.Fatal(err)
+      }
+    }
+    // Verify that the next tx of the sender evicts the tail tx of another
+    // account instead of the ready tail tx of the sender
+    err = apply(acc, 9, 3, 5)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if mempool.Len() != 3 || len(mempool.Txs()) != 2 {
+      t.Errorf("invalid ready txs: expected 2, got %v", len(mempool.Txs()))
+    }
+    if mempool.Nonce(acc.Address()) != 5 {
+      t.Errorf(
+        "invalid nonce: expected 5, got %v", mempool.Nonce(acc.Address()),
+      )
+    }
+  })
+  t.Run("pending tx replaced", func(t *testing.T) {
+    // Verify that the replacement without a higher fee is rejected
+    err := apply(acc, 0, 3, 5)
+    if err == nil {
+      t.Errorf("expected replacement fee too low error, got none")
+    }
+    // Cancel the ready tx with the zero-value self-transfer with a higher fee
+    tx := chain.NewTx(chainName, acc.Address(), acc.Address(), 0, 5)
+    tx.Fee = 4
+    stx, err := acc.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = mempool.ApplyTx(stx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Replace the future tx of another account with a higher fee
+    err = apply(acc2, 1, 3, 5)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the replaced txs are dropped and the replacements are kept
+    txs := mempool.Txs()
+    if mempool.Len() != 3 || len(txs) != 2 {
+      t.Errorf("invalid ready txs: expected 2, got %v", len(txs))
+    }
+    if txs[len(txs) - 1].Hash() != stx.Hash() {
+      t.Errorf("invalid ready tx: expected cancel tx %v", stx.Hash())
+    }
+    // Verify that the replaced events are published for both replaced txs
+    if len(evRec.events) != 2 {
+      t.Fatalf("invalid events: expected 2, got %v", len(evRec.events))
+    }
+    for _, event := range evRec.events {
+      if event.Type != chain.EvTx || event.Action != "replaced" {
+        t.Errorf("invalid event: expected tx replaced, got %v", event)
+      }
+    }
+  })
+  t.Run("expired tx purged", func(t *testing.T) {
+    // Verify that the already expired tx is rejected
+    expiry := &chain.TxExpiry{Time: time.Now().Add(-time.Second)}
+    stx, err := signTx(acc2, 1, 5, 5, expiry)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = mempool.ApplyTx(stx)
+    if err == nil {
+      t.Errorf("expected expired transaction error, got none")
+    }
+    // Replace the future tx of another account with the tx that expires soon
+    expiry = &chain.TxExpiry{Time: time.Now().Add(50 * time.Millisecond)}
+    stx, err = signTx(acc2, 1, 5, 5, expiry)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = mempool.ApplyTx(stx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Wait for the tx to expire and re-validate the mempool
+    time.Sleep(100 * time.Millisecond)
+    mempool.Revalidate(nil)
+    // Verify that the expired tx is purged and the expired event is published
+    if mempool.Len() != 2 {
+      t.Errorf("invalid mempool txs: expected 2, got %v", mempool.Len())
+    }
+    event := evRec.events[len(evRec.events) - 1]
+    if event.Type != chain.EvTx || event.Action != "expired" {
+      t.Errorf("invalid event: expected tx expired, got %v", event)
+    }
+  })
+  t.Run("journal reloaded", func(t *testing.T) {
+    // Open the journal of the mempool and journal the next tx
+    _, err := mempool.LoadJournal(bootBlockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = apply(acc2, 1, 5, 7)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Simulate the interrupted write at the end of the journal
+    path := filepath.Join(bootBlockStoreDir, "mempool.journal")
+    file, err := os.OpenFile(path, os.O_APPEND | os.O_WRONLY, 0600)
+    if err != nil {
+      t.Fatal(err)
+    }
+    _, err = file.WriteString(`{"chain":`)
+    if err != nil {
+      t.Fatal(err)
+    }
+    file.Close()
+    // Reload the journal into the new mempool on the confirmed state
+    mempool2 := node.NewMempool(ctx, wg, 3, 0)
+    mempool2.SetState(state)
+    mempool2.Revalidate(nil)
+    txs, err := mempool2.LoadJournal(bootBlockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the pending txs are restored and returned for the re-relay
+    if len(txs) != mempool.Len() || mempool2.Len() != mempool.Len() {
+      t.Errorf(
+        "invalid pending txs: expected %v, got %v", mempool.Len(), len(txs),
+      )
+    }
+    exp, got := mempool.Txs(), mempool2.Txs()
+    if len(got) != len(exp) {
+      t.Fatalf("invalid ready txs: expected %v, got %v", len(exp), len(got))
+    }
+    for i := range exp {
+      if got[i].Hash() != exp[i].Hash() {
+        t.Errorf(
+          "invalid ready tx: expected %v, got %v", exp[i].Hash(), got[i].Hash(),
+        )
+      }
+    }
+  })
+}
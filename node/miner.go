@@ -0,0 +1,74 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+)
+
+// Miner mines blocks from the pending txs in the proof of work consensus. The
+// mining of a block is abandoned as soon as a new block is confirmed
+type Miner struct {
+  ctx context.Context
+  wg *sync.WaitGroup
+  miner chain.Account
+  state *chain.State
+  mempool *Mempool
+  blkRelayer BlockRelayer
+}
+
+func NewMiner(
+  ctx context.Context, wg *sync.WaitGroup, blkRelayer BlockRelayer,
+) *Miner {
+  return &Miner{ctx: ctx, wg: wg, blkRelayer: blkRelayer}
+}
+
+func (m *Miner) SetMiner(miner chain.Account) {
+  m.miner = miner
+}
+
+func (m *Miner) SetState(state *chain.State) {
+  m.state = state
+}
+
+func (m *Miner) SetMempool(mempool *Mempool) {
+  m.mempool = mempool
+}
+
+func (m *Miner) MineBlocks(period time.Duration) {
+  defer m.wg.Done()
+  tick := time.NewTicker(period)
+  defer tick.Stop()
+  for {
+    select {
+    case <- m.ctx.Done():
+      return
+    case <- tick.C:
+      hash := m.state.LastBlock().Hash()
+      stop := func() bool {
+        return m.ctx.Err() != nil || m.state.LastBlock().Hash() != hash
+      }
+      clone := m.state.Clone()
+      if m.mempool != nil {
+        clone.SetPendingTxs(m.mempool.Txs())
+      }
+      blk, err := clone.MineBlock(m.miner, stop)
+      if err != nil {
+        continue
+      }
+      clone = m.state.Clone()
+      err = clone.ApplyBlock(blk)
+      if err != nil {
+        fmt.Println(err)
+        continue
+      }
+      if m.blkRelayer != nil {
+        m.blkRelayer.RelayBlock(blk)
+      }
+      fmt.Printf("==> Block mine\n%v", blk)
+    }
+  }
+}
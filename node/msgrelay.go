@@ -79,6 +79,68 @@ var GRPCBlockRelay GRPCMsgRelay[chain.SigBlock] = func(
   }
 }
 
+var GRPCConsensusRelay GRPCMsgRelay[chain.ConsensusMsg] = func(
+  ctx context.Context, conn *grpc.ClientConn, chRelay chan chain.ConsensusMsg,
+) error {
+  cln := rpc.NewConsensusClient(conn)
+  stream, err := cln.ConsensusReceive(ctx)
+  if err != nil {
+    return err
+  }
+  defer stream.CloseAndRecv()
+  for {
+    select {
+    case <- ctx.Done():
+      return nil
+    case msg, open := <- chRelay:
+      if !open {
+        return nil
+      }
+      jmsg, err := json.Marshal(msg)
+      if err != nil {
+        fmt.Println(err)
+        continue
+      }
+      req := &rpc.ConsensusReceiveReq{Msg: jmsg}
+      err = stream.Send(req)
+      if err != nil {
+        return err
+      }
+    }
+  }
+}
+
+var GRPCEvidenceRelay GRPCMsgRelay[chain.Evidence] = func(
+  ctx context.Context, conn *grpc.ClientConn, chRelay chan chain.Evidence,
+) error {
+  cln := rpc.NewEvidenceClient(conn)
+  stream, err := cln.EvidenceReceive(ctx)
+  if err != nil {
+    return err
+  }
+  defer stream.CloseAndRecv()
+  for {
+    select {
+    case <- ctx.Done():
+      return nil
+    case ev, open := <- chRelay:
+      if !open {
+        return nil
+      }
+      jev, err := json.Marshal(ev)
+      if err != nil {
+        fmt.Println(err)
+        continue
+      }
+      req := &rpc.EvidenceReceiveReq{Evidence: jev}
+      err = stream.Send(req)
+      if err != nil {
+        return err
+      }
+    }
+  }
+}
+
 type MsgRelay[Msg any, Relay GRPCMsgRelay[Msg]] struct {
   ctx context.Context
   wg *sync.WaitGroup
@@ -110,6 +172,26 @@ func (r *MsgRelay[Msg, Relay]) RelayBlock(blk Msg) {
   r.chMsg <- blk
 }
 
+// RelayConsensus drops the message when the relay lags behind, so the
+// consensus never blocks on the relay. The missed message is recovered by the
+// round timeout
+func (r *MsgRelay[Msg, Relay]) RelayConsensus(msg Msg) {
+  select {
+  case r.chMsg <- msg:
+  default:
+  }
+}
+
+// RelayEvidence drops the evidence when the relay lags behind, so the block
+// processing never blocks on the relay. The evidence is still included in the
+// next block proposed by the node
+func (r *MsgRelay[Msg, Relay]) RelayEvidence(ev Msg) {
+  select {
+  case r.chMsg <- ev:
+  default:
+  }
+}
+
 func (r *MsgRelay[Msg, Relay]) addPeers(period time.Duration) {
   defer r.wgRelays.Done()
   tick := time.NewTicker(period)
@@ -39,7 +39,7 @@ func sendTxs(
   cln := rpc.NewTxClient(conn)
   for _, value := range values {
     tx := chain.NewTx(
-      acc.Address(), chain.Address("to"), value,
+      chainName, acc.Address(), chain.Address("to"), value,
       pending.Nonce(acc.Address()) + 1,
     )
     stx, err := acc.SignTx(tx)
@@ -81,10 +81,16 @@ func TestTxRelay(t *testing.T) {
     node := rpc.NewNodeSrv(bootPeerDisc, nil)
     rpc.RegisterNodeServer(grpcSrv, node)
     tx := rpc.NewTxSrv(
-      bootKeyStoreDir, bootBlockStoreDir, bootState.Pending, bootTxRelay,
+      bootKeyStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      bootState.Pending, bootTxRelay, 0,
     )
     rpc.RegisterTxServer(grpcSrv, tx)
-    blk := rpc.NewBlockSrv(bootBlockStoreDir, nil, bootState, nil)
+    blk := rpc.NewBlockSrv(
+      bootBlockStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      nil, bootState, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Create and start the peer discovery for the new node
@@ -99,7 +105,10 @@ func TestTxRelay(t *testing.T) {
   }
   // Start the gRPC server on the new node
   grpcStartSvr(t, nodeAddr, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, nodeState.Pending, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir, chain.KVStoreType),
+      nodeState.Pending, nil, 0,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
   })
   // Wait for the gRPC server of the new node to start
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
 	"os/signal"
 	"path/filepath"
 	"sync"
@@ -20,14 +21,31 @@ type NodeCfg struct {
   NodeAddr string
   Bootstrap bool
   SeedAddr string
+  FastSync bool
   // Stores
   KeyStoreDir string
   BlockStoreDir string
+  StoreType string
+  // Snapshots
+  SnapshotInterval uint64
+  Replay bool
+  Archive bool
   // Genesis
   Chain string
   AuthPass string
+  Validators []string
+  Consensus string
+  Difficulty uint64
+  BlockTime time.Duration
+  Miner string
+  MaxTxs uint64
+  MaxBytes uint64
   OwnerPass string
   Balance uint64
+  // Tx policy
+  MinFee uint64
+  MempoolCap int
+  MempoolTTL time.Duration
   // Processes
   Period time.Duration
 }
@@ -48,6 +66,11 @@ type Node struct {
   txRelay *MsgRelay[chain.SigTx, GRPCMsgRelay[chain.SigTx]]
   blockProp *BlockProposer
   blkRelay *MsgRelay[chain.SigBlock, GRPCMsgRelay[chain.SigBlock]]
+  consensus *Consensus
+  csRelay *MsgRelay[chain.ConsensusMsg, GRPCMsgRelay[chain.ConsensusMsg]]
+  miner *Miner
+  evRelay *MsgRelay[chain.Evidence, GRPCMsgRelay[chain.Evidence]]
+  mempool *Mempool
 }
 
 func NewNode(cfg NodeCfg) *Node {
@@ -64,10 +87,21 @@ func NewNode(cfg NodeCfg) *Node {
   txRelay := NewMsgRelay(ctx, wg, 100, GRPCTxRelay, false, peerDisc)
   blkRelay := NewMsgRelay(ctx, wg, 10, GRPCBlockRelay, true, peerDisc)
   blockProp := NewBlockProposer(ctx, wg, blkRelay)
+  csRelay := NewMsgRelay(ctx, wg, 100, GRPCConsensusRelay, true, peerDisc)
+  consensus := NewConsensus(ctx, wg, csRelay, blkRelay)
+  miner := NewMiner(ctx, wg, blkRelay)
+  evRelay := NewMsgRelay(ctx, wg, 10, GRPCEvidenceRelay, false, peerDisc)
+  stateSync.SetEvidenceRelayer(evRelay)
+  consensus.SetEvidenceRelayer(evRelay)
+  mempool := NewMempool(ctx, wg, cfg.MempoolCap, cfg.MempoolTTL)
+  mempool.SetEventPublisher(evStream)
+  stateSync.SetMempool(mempool)
   return &Node{
     cfg: cfg, ctx: ctx, ctxCancel: cancel, wg: wg, chErr: make(chan error, 1),
     evStream: evStream, stateSync: stateSync, peerDisc: peerDisc,
     txRelay: txRelay, blockProp: blockProp, blkRelay: blkRelay,
+    consensus: consensus, csRelay: csRelay, miner: miner, evRelay: evRelay,
+    mempool: mempool,
   }
 }
 
@@ -80,22 +114,48 @@ func (n *Node) Start() error {
     return err
   }
   n.state = state
+  n.mempool.SetState(n.state)
+  txs, err := n.mempool.LoadJournal(n.cfg.BlockStoreDir)
+  if err != nil {
+    return err
+  }
+  n.wg.Add(1)
+  go n.mempool.PurgeTxs(n.cfg.Period)
   n.wg.Add(1)
   go n.servegRPC()
   n.wg.Add(1)
   go n.peerDisc.DiscoverPeers(n.cfg.Period)
   n.wg.Add(1)
   go n.txRelay.RelayMsgs(n.cfg.Period)
-  if n.cfg.Bootstrap {
-    path := filepath.Join(n.cfg.KeyStoreDir, string(n.state.Authority()))
-    auth, err := chain.ReadAccount(path, []byte(n.cfg.AuthPass))
-    if err != nil {
-      return err
-    }
+  n.wg.Add(1)
+  go n.relayJournal(txs)
+  n.wg.Add(1)
+  go n.evRelay.RelayMsgs(n.cfg.Period)
+  auth, validator, err := n.readValidator()
+  if err != nil {
+    return err
+  }
+  switch {
+  case validator && n.state.Consensus() == chain.ConsensusPoW:
+    n.miner.SetMiner(auth)
+    n.miner.SetState(n.state)
+    n.miner.SetMempool(n.mempool)
+    n.wg.Add(1)
+    go n.miner.MineBlocks(n.cfg.Period)
+  case validator && n.state.Consensus() == chain.ConsensusBFT:
+    n.consensus.SetValidator(auth)
+    n.consensus.SetState(n.state)
+    n.consensus.SetMempool(n.mempool)
+    n.wg.Add(1)
+    go n.csRelay.RelayMsgs(n.cfg.Period)
+    n.wg.Add(1)
+    go n.consensus.RunConsensus(n.cfg.Period)
+  case validator:
     n.blockProp.SetAuthority(auth)
     n.blockProp.SetState(n.state)
+    n.blockProp.SetMempool(n.mempool)
     n.wg.Add(1)
-    go n.blockProp.ProposeBlocks(n.cfg.Period * 2)
+    go n.blockProp.ProposeBlocks(n.cfg.Period)
   }
   n.wg.Add(1)
   go n.blkRelay.RelayMsgs(n.cfg.Period)
@@ -107,9 +167,69 @@ func (n *Node) Start() error {
   n.ctxCancel() // restore default signal handling
   n.grpcSrv.GracefulStop()
   n.wg.Wait()
+  closeErr := n.stateSync.BlockStore().Close()
+  if err == nil {
+    err = closeErr
+  }
+  if n.cfg.Archive {
+    closeErr = n.stateSync.Archive().Close()
+    if err == nil {
+      err = closeErr
+    }
+  }
   return err
 }
 
+// relayJournal re-relays the pending txs reloaded from the mempool journal,
+// after the tx relay is connected to the discovered peers
+func (n *Node) relayJournal(txs []chain.SigTx) {
+  defer n.wg.Done()
+  if len(txs) == 0 {
+    return
+  }
+  select {
+  case <- n.ctx.Done():
+    return
+  case <- time.After(n.cfg.Period * 2):
+  }
+  for _, tx := range txs {
+    select {
+    case <- n.ctx.Done():
+      return
+    default:
+      n.txRelay.RelayTx(tx)
+    }
+  }
+}
+
+// readValidator reads the validator account from the key store when the node
+// is provided with the authority password and the key store contains the
+// account of a validator from the validator set. In the proof of work
+// consensus the validator is the miner
+func (n *Node) readValidator() (chain.Account, bool, error) {
+  if len(n.cfg.AuthPass) == 0 {
+    return chain.Account{}, false, nil
+  }
+  vals := n.state.Validators()
+  if n.state.Consensus() == chain.ConsensusPoW && len(n.cfg.Miner) > 0 {
+    vals = []chain.Address{chain.Address(n.cfg.Miner)}
+  }
+  for _, val := range vals {
+    path := filepath.Join(n.cfg.KeyStoreDir, string(val))
+    _, err := os.Stat(path)
+    if err != nil {
+      continue
+    }
+    auth, err := chain.ReadAccount(path, []byte(n.cfg.AuthPass))
+    if err != nil {
+      return chain.Account{}, false, err
+    }
+    fmt.Printf("=== Validator %v\n", auth.Address())
+    return auth, true, nil
+  }
+  return chain.Account{}, false, nil
+}
+
 func (n *Node) GracefulStop() {
   n.ctxCancel()
 }
@@ -126,14 +246,32 @@ func (n *Node) servegRPC() {
   n.grpcSrv = grpc.NewServer()
   node := rpc.NewNodeSrv(n.peerDisc, n.evStream)
   rpc.RegisterNodeServer(n.grpcSrv, node)
-  acc := rpc.NewAccountSrv(n.cfg.KeyStoreDir, n.state)
+  blockStore := n.stateSync.BlockStore()
+  var archive rpc.AccountArchive
+  if n.cfg.Archive {
+    archive = n.stateSync.Archive()
+  }
+  acc := rpc.NewAccountSrv(
+    n.cfg.KeyStoreDir, n.cfg.BlockStoreDir, blockStore, archive, n.state,
+  )
   rpc.RegisterAccountServer(n.grpcSrv, acc)
   tx := rpc.NewTxSrv(
-    n.cfg.KeyStoreDir, n.cfg.BlockStoreDir, n.state.Pending, n.txRelay,
+    n.cfg.KeyStoreDir, blockStore, n.mempool, n.txRelay,
+    n.cfg.MinFee,
   )
   rpc.RegisterTxServer(n.grpcSrv, tx)
-  blk := rpc.NewBlockSrv(n.cfg.BlockStoreDir, n.evStream, n.state, n.blkRelay)
+  val := rpc.NewValidatorSrv(n.cfg.KeyStoreDir, n.state, n.mempool)
+  rpc.RegisterValidatorServer(n.grpcSrv, val)
+  mem := rpc.NewMempoolSrv(n.mempool)
+  rpc.RegisterMempoolServer(n.grpcSrv, mem)
+  blk := rpc.NewBlockSrv(
+    n.cfg.BlockStoreDir, blockStore, n.evStream, n.stateSync, n.blkRelay,
+  )
   rpc.RegisterBlockServer(n.grpcSrv, blk)
+  cs := rpc.NewConsensusSrv(n.consensus)
+  rpc.RegisterConsensusServer(n.grpcSrv, cs)
+  ev := rpc.NewEvidenceSrv(n.state.Pending, n.evRelay)
+  rpc.RegisterEvidenceServer(n.grpcSrv, ev)
   err = n.grpcSrv.Serve(lis)
   if err != nil {
     n.chErr <- err
@@ -29,7 +29,7 @@ func TestNodeStart(t *testing.T) {
     KeyStoreDir: bootKeyStoreDir, BlockStoreDir: bootBlockStoreDir,
     Chain: chainName, AuthPass: authPass,
     OwnerPass: ownerPass, Balance: ownerBalance,
-    Period: 100 * time.Millisecond,
+    Period: 400 * time.Millisecond,
   }
   nd := node.NewNode(nodeCfg)
   // Start the bootstrap node in a separate goroutine
@@ -73,7 +73,7 @@ func TestNodeStart(t *testing.T) {
     for i, value := range []uint64{12, 34} {
       // Create and sign a new transaction
       tx := chain.NewTx(
-        acc.Address(), chain.Address("to"), value, uint64(i + 1),
+        chainName, acc.Address(), chain.Address("to"), value, uint64(i + 1),
       )
       stx, err := acc.SignTx(tx)
       if err != nil {
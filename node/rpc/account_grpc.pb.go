@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Account_AccountCreate_FullMethodName  = "/Account/AccountCreate"
 	Account_AccountBalance_FullMethodName = "/Account/AccountBalance"
+	Account_AccountNonce_FullMethodName   = "/Account/AccountNonce"
+	Account_AccountProve_FullMethodName   = "/Account/AccountProve"
+	Account_AccountVerify_FullMethodName  = "/Account/AccountVerify"
+	Account_AccountHistory_FullMethodName = "/Account/AccountHistory"
 )
 
 // AccountClient is the client API for Account service.
@@ -29,6 +33,10 @@ const (
 type AccountClient interface {
 	AccountCreate(ctx context.Context, in *AccountCreateReq, opts ...grpc.CallOption) (*AccountCreateRes, error)
 	AccountBalance(ctx context.Context, in *AccountBalanceReq, opts ...grpc.CallOption) (*AccountBalanceRes, error)
+	AccountNonce(ctx context.Context, in *AccountNonceReq, opts ...grpc.CallOption) (*AccountNonceRes, error)
+	AccountProve(ctx context.Context, in *AccountProveReq, opts ...grpc.CallOption) (*AccountProveRes, error)
+	AccountVerify(ctx context.Context, in *AccountVerifyReq, opts ...grpc.CallOption) (*AccountVerifyRes, error)
+	AccountHistory(ctx context.Context, in *AccountHistoryReq, opts ...grpc.CallOption) (*AccountHistoryRes, error)
 }
 
 type accountClient struct {
@@ -59,12 +67,56 @@ func (c *accountClient) AccountBalance(ctx context.Context, in *AccountBalanceRe
 	return out, nil
 }
 
+func (c *accountClient) AccountNonce(ctx context.Context, in *AccountNonceReq, opts ...grpc.CallOption) (*AccountNonceRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountNonceRes)
+	err := c.cc.Invoke(ctx, Account_AccountNonce_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) AccountProve(ctx context.Context, in *AccountProveReq, opts ...grpc.CallOption) (*AccountProveRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountProveRes)
+	err := c.cc.Invoke(ctx, Account_AccountProve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) AccountVerify(ctx context.Context, in *AccountVerifyReq, opts ...grpc.CallOption) (*AccountVerifyRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountVerifyRes)
+	err := c.cc.Invoke(ctx, Account_AccountVerify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) AccountHistory(ctx context.Context, in *AccountHistoryReq, opts ...grpc.CallOption) (*AccountHistoryRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountHistoryRes)
+	err := c.cc.Invoke(ctx, Account_AccountHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServer is the server API for Account service.
 // All implementations must embed UnimplementedAccountServer
 // for forward compatibility.
 type AccountServer interface {
 	AccountCreate(context.Context, *AccountCreateReq) (*AccountCreateRes, error)
 	AccountBalance(context.Context, *AccountBalanceReq) (*AccountBalanceRes, error)
+	AccountNonce(context.Context, *AccountNonceReq) (*AccountNonceRes, error)
+	AccountProve(context.Context, *AccountProveReq) (*AccountProveRes, error)
+	AccountVerify(context.Context, *AccountVerifyReq) (*AccountVerifyRes, error)
+	AccountHistory(context.Context, *AccountHistoryReq) (*AccountHistoryRes, error)
 	mustEmbedUnimplementedAccountServer()
 }
 
@@ -81,6 +133,18 @@ func (UnimplementedAccountServer) AccountCreate(context.Context, *AccountCreateR
 func (UnimplementedAccountServer) AccountBalance(context.Context, *AccountBalanceReq) (*AccountBalanceRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AccountBalance not implemented")
 }
+func (UnimplementedAccountServer) AccountNonce(context.Context, *AccountNonceReq) (*AccountNonceRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNonce not implemented")
+}
+func (UnimplementedAccountServer) AccountProve(context.Context, *AccountProveReq) (*AccountProveRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountProve not implemented")
+}
+func (UnimplementedAccountServer) AccountVerify(context.Context, *AccountVerifyReq) (*AccountVerifyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountVerify not implemented")
+}
+func (UnimplementedAccountServer) AccountHistory(context.Context, *AccountHistoryReq) (*AccountHistoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountHistory not implemented")
+}
 func (UnimplementedAccountServer) mustEmbedUnimplementedAccountServer() {}
 func (UnimplementedAccountServer) testEmbeddedByValue()                 {}
 
@@ -138,6 +202,78 @@ func _Account_AccountBalance_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Account_AccountNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountNonceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).AccountNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_AccountNonce_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).AccountNonce(ctx, req.(*AccountNonceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_AccountProve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountProveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).AccountProve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_AccountProve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).AccountProve(ctx, req.(*AccountProveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_AccountVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountVerifyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).AccountVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_AccountVerify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).AccountVerify(ctx, req.(*AccountVerifyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_AccountHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).AccountHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_AccountHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).AccountHistory(ctx, req.(*AccountHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Account_ServiceDesc is the grpc.ServiceDesc for Account service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +289,22 @@ var Account_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AccountBalance",
 			Handler:    _Account_AccountBalance_Handler,
 		},
+		{
+			MethodName: "AccountNonce",
+			Handler:    _Account_AccountNonce_Handler,
+		},
+		{
+			MethodName: "AccountProve",
+			Handler:    _Account_AccountProve_Handler,
+		},
+		{
+			MethodName: "AccountVerify",
+			Handler:    _Account_AccountVerify_Handler,
+		},
+		{
+			MethodName: "AccountHistory",
+			Handler:    _Account_AccountHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "account.proto",
@@ -2,27 +2,44 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-type BalanceChecker interface {
+type AccountReader interface {
   Balance(acc chain.Address) (uint64, bool)
+  Nonce(acc chain.Address) uint64
+  LastBlock() chain.SigBlock
+  ProveAccount(acc chain.Address) (chain.AccProof, error)
+}
+
+type AccountArchive interface {
+  Account(acc chain.Address, number uint64) (chain.AccState, bool, error)
 }
 
 type AccountSrv struct {
   UnimplementedAccountServer
   keyStoreDir string
-  balChecker BalanceChecker
+  blockStoreDir string
+  blockStore chain.BlockStore
+  archive AccountArchive
+  accReader AccountReader
 }
 
 func NewAccountSrv(
-  keyStoreDir string, balChecker BalanceChecker,
+  keyStoreDir, blockStoreDir string, blockStore chain.BlockStore,
+  archive AccountArchive, accReader AccountReader,
 ) *AccountSrv {
-  return &AccountSrv{keyStoreDir: keyStoreDir, balChecker: balChecker}
+  return &AccountSrv{
+    keyStoreDir: keyStoreDir, blockStoreDir: blockStoreDir,
+    blockStore: blockStore, archive: archive, accReader: accReader,
+  }
 }
 
 func (s *AccountSrv) AccountCreate(
@@ -46,18 +63,213 @@ func (s *AccountSrv) AccountCreate(
   return res, nil
 }
 
-func (s *AccountSrv) AccountBalance(
-  _ context.Context, req *AccountBalanceReq,
-) (*AccountBalanceRes, error) {
-  acc := req.Address
-  balance, exist := s.balChecker.Balance(chain.Address(acc))
+// accountAt returns the account state at the block number from the archive in
+// the archival mode. Otherwise, the account state is read from the state at the
+// block number
+func (s *AccountSrv) accountAt(
+  acc chain.Address, number uint64,
+) (chain.AccState, error) {
+  lastNumber := s.accReader.LastBlock().Number
+  var accState chain.AccState
+  exist := false
+  if s.archive != nil && number != 0 && number <= lastNumber {
+    var err error
+    accState, exist, err = s.archive.Account(acc, number)
+    if err != nil {
+      return chain.AccState{}, status.Errorf(codes.Internal, err.Error())
+    }
+  } else {
+    state, err := s.stateAt(number)
+    if err != nil {
+      return chain.AccState{}, err
+    }
+    accState.Account = acc
+    accState.Balance, exist = state.Balance(acc)
+    accState.Nonce = state.Nonce(acc)
+  }
   if !exist {
-    return nil, status.Errorf(
+    return chain.AccState{}, status.Errorf(
       codes.NotFound, fmt.Sprintf(
         "account %v does not exist or has not yet transacted", acc,
       ),
     )
   }
-  res := &AccountBalanceRes{Balance: balance}
+  return accState, nil
+}
+
+func (s *AccountSrv) AccountBalance(
+  _ context.Context, req *AccountBalanceReq,
+) (*AccountBalanceRes, error) {
+  accState, err := s.accountAt(chain.Address(req.Address), req.Number)
+  if err != nil {
+    return nil, err
+  }
+  res := &AccountBalanceRes{Balance: accState.Balance}
+  return res, nil
+}
+
+func (s *AccountSrv) AccountNonce(
+  _ context.Context, req *AccountNonceReq,
+) (*AccountNonceRes, error) {
+  accState, err := s.accountAt(chain.Address(req.Address), req.Number)
+  if err != nil {
+    return nil, err
+  }
+  res := &AccountNonceRes{Nonce: accState.Nonce}
+  return res, nil
+}
+
+// stateAt returns the current state for the block number 0 or the last block
+// number. Otherwise, the state at the block number is reconstructed from the
+// block store
+func (s *AccountSrv) stateAt(number uint64) (AccountReader, error) {
+  lastNumber := s.accReader.LastBlock().Number
+  if number == 0 || number == lastNumber {
+    return s.accReader, nil
+  }
+  if number > lastNumber {
+    return nil, status.Errorf(
+      codes.NotFound, fmt.Sprintf("block %v not found", number),
+    )
+  }
+  gen, err := chain.ReadGenesis(s.blockStoreDir)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  state, err := chain.ReplayState(gen, s.blockStore, number)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  return state, nil
+}
+
+func (s *AccountSrv) AccountProve(
+  _ context.Context, req *AccountProveReq,
+) (*AccountProveRes, error) {
+  state, err := s.stateAt(req.Number)
+  if err != nil {
+    return nil, err
+  }
+  accProof, err := state.ProveAccount(chain.Address(req.Address))
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  jap, err := json.Marshal(accProof)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &AccountProveRes{AccountProof: jap}
+  return res, nil
+}
+
+func (s *AccountSrv) AccountVerify(
+  _ context.Context, req *AccountVerifyReq,
+) (*AccountVerifyRes, error) {
+  var accProof chain.AccProof
+  err := json.Unmarshal(req.AccountProof, &accProof)
+  if err != nil {
+    return nil, status.Errorf(codes.InvalidArgument, err.Error())
+  }
+  stateRoot, err := chain.DecodeHash(req.StateRoot)
+  if err != nil {
+    return nil, status.Errorf(codes.InvalidArgument, err.Error())
+  }
+  valid := accProof.Account == chain.Address(req.Address) &&
+    chain.VerifyAccount(accProof, stateRoot)
+  res := &AccountVerifyRes{Valid: valid}
+  if valid && accProof.Exist {
+    leaf := accProof.Leaves[0].Leaf
+    res.Exist, res.Balance, res.Nonce = true, leaf.Balance, leaf.Nonce
+  }
+  return res, nil
+}
+
+const (
+  historyLimit = 100
+  historyMaxLimit = 1000
+)
+
+func encodeHistoryCursor(pos chain.TxPos) string {
+  return fmt.Sprintf("%v:%v", pos.Number, pos.Index)
+}
+
+func decodeHistoryCursor(cursor string) (chain.TxPos, error) {
+  number, index, found := strings.Cut(cursor, ":")
+  if !found {
+    return chain.TxPos{}, fmt.Errorf("invalid cursor %v", cursor)
+  }
+  var pos chain.TxPos
+  var err error
+  pos.Number, err = strconv.ParseUint(number, 10, 64)
+  if err != nil {
+    return chain.TxPos{}, fmt.Errorf("invalid cursor %v", cursor)
+  }
+  pos.Index, err = strconv.ParseUint(index, 10, 64)
+  if err != nil {
+    return chain.TxPos{}, fmt.Errorf("invalid cursor %v", cursor)
+  }
+  return pos, nil
+}
+
+// AccountHistory returns the incoming and outgoing txs of the account ordered
+// by the block number and the tx index in the block. The returned cursor
+// continues the history after the last returned tx
+func (s *AccountSrv) AccountHistory(
+  _ context.Context, req *AccountHistoryReq,
+) (*AccountHistoryRes, error) {
+  acc := chain.Address(req.Address)
+  if req.Direction != "" && req.Direction != "in" && req.Direction != "out" {
+    return nil, status.Errorf(
+      codes.InvalidArgument,
+      fmt.Sprintf("invalid direction %v: expected in or out", req.Direction),
+    )
+  }
+  var after chain.TxPos
+  if len(req.Cursor) > 0 {
+    var err error
+    after, err = decodeHistoryCursor(req.Cursor)
+    if err != nil {
+      return nil, status.Errorf(codes.InvalidArgument, err.Error())
+    }
+  }
+  limit := int(req.Limit)
+  if limit == 0 {
+    limit = historyLimit
+  }
+  limit = min(limit, historyMaxLimit)
+  poss, err := s.blockStore.AccountTxs(acc)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &AccountHistoryRes{}
+  var blk chain.SigBlock
+  for i, pos := range poss {
+    if len(req.Cursor) > 0 && !after.Less(pos) ||
+      pos.Number < req.FromBlock ||
+      req.ToBlock != 0 && pos.Number > req.ToBlock {
+      continue
+    }
+    if len(res.Txs) == limit {
+      res.Cursor = encodeHistoryCursor(poss[i - 1])
+      break
+    }
+    if blk.Number != pos.Number {
+      blk, err = s.blockStore.Block(pos.Number)
+      if err != nil {
+        return nil, status.Errorf(codes.Internal, err.Error())
+      }
+    }
+    tx := blk.Txs[pos.Index]
+    if req.Direction == "in" && tx.To != acc ||
+      req.Direction == "out" && tx.From != acc {
+      continue
+    }
+    stx := chain.NewSearchTx(tx, blk.Number, blk.Hash(), blk.MerkleRoot)
+    jtx, err := json.Marshal(stx)
+    if err != nil {
+      return nil, status.Errorf(codes.Internal, err.Error())
+    }
+    res.Txs = append(res.Txs, jtx)
+  }
   return res, nil
 }
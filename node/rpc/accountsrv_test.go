@@ -2,10 +2,12 @@ package rpc_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
@@ -67,6 +69,14 @@ func genesisAccount(gen chain.SigGenesis) (chain.Address, uint64) {
   return "", 0
 }
 
+func openBlockStore(t *testing.T, blockStoreDir string) chain.BlockStore {
+  blockStore, err := chain.OpenBlockStore(blockStoreDir, chain.FileStoreType)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return blockStore
+}
+
 func grpcClientConn(
   t *testing.T, grpcRegisterSrv func(grpcSrv *grpc.Server),
 ) *grpc.ClientConn {
@@ -109,7 +119,7 @@ func TestAccountCreate(t *testing.T) {
   defer cancel()
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    acc := rpc.NewAccountSrv(keyStoreDir, nil)
+    acc := rpc.NewAccountSrv(keyStoreDir, blockStoreDir, nil, nil, nil)
     rpc.RegisterAccountServer(grpcSrv, acc)
   })
   // Create the gRPC account client
@@ -144,7 +154,7 @@ func TestAccountBalance(t *testing.T) {
   ownerAcc, ownerBal := genesisAccount(gen)
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    acc := rpc.NewAccountSrv(keyStoreDir, state)
+    acc := rpc.NewAccountSrv(keyStoreDir, blockStoreDir, nil, nil, state)
     rpc.RegisterAccountServer(grpcSrv, acc)
   })
   // Create the gRPC account client
@@ -177,3 +187,319 @@ func TestAccountBalance(t *testing.T) {
     }
   })
 }
+
+func TestAccountProveVerify(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state from the genesis
+  state := chain.NewState(gen)
+  // Create several confirmed blocks on the state and on the local block store
+  err = createBlocks(gen, state)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blockStore := openBlockStore(t, blockStoreDir)
+  ownerAcc, _ := genesisAccount(gen)
+  // Set up the gRPC server and client
+  conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+    acc := rpc.NewAccountSrv(
+      keyStoreDir, blockStoreDir, blockStore, nil, state,
+    )
+    rpc.RegisterAccountServer(grpcSrv, acc)
+  })
+  // Create the gRPC account client
+  cln := rpc.NewAccountClient(conn)
+  for _, number := range []uint64{1, 2} {
+    t.Run(fmt.Sprintf("prove account at block %v", number), func(t *testing.T) {
+      // Call the AccountProve method to get the Merkle proof of the account at
+      // the block number
+      proveReq := &rpc.AccountProveReq{
+        Address: string(ownerAcc), Number: number,
+      }
+      proveRes, err := cln.AccountProve(ctx, proveReq)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Get the state root from the block at the block number
+      blk, err := blockStore.Block(number)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Call the AccountVerify method to verify the account proof against the
+      // state root of the block
+      verifyReq := &rpc.AccountVerifyReq{
+        Address: string(ownerAcc), AccountProof: proveRes.AccountProof,
+        StateRoot: blk.StateRoot.String(),
+      }
+      verifyRes, err := cln.AccountVerify(ctx, verifyReq)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that the account proof is valid and the account nonce is equal
+      // to the number of account transactions up to the block number
+      if !verifyRes.Valid || !verifyRes.Exist {
+        t.Fatalf("invalid account proof")
+      }
+      if verifyRes.Nonce != number {
+        t.Errorf(
+          "invalid nonce: expected %v, got %v", number, verifyRes.Nonce,
+        )
+      }
+    })
+  }
+  t.Run("verify proof against another block", func(t *testing.T) {
+    // Call the AccountProve method to get the Merkle proof of the account at
+    // the first block
+    proveReq := &rpc.AccountProveReq{Address: string(ownerAcc), Number: 1}
+    proveRes, err := cln.AccountProve(ctx, proveReq)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the account proof is invalid against the last block
+    verifyReq := &rpc.AccountVerifyReq{
+      Address: string(ownerAcc), AccountProof: proveRes.AccountProof,
+      StateRoot: state.LastBlock().StateRoot.String(),
+    }
+    verifyRes, err := cln.AccountVerify(ctx, verifyReq)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if verifyRes.Valid {
+      t.Errorf("account proof is valid against another block")
+    }
+  })
+}
+
+func TestAccountBalanceNonceAt(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state from the genesis
+  state := chain.NewState(gen)
+  // Create several confirmed blocks on the state and on the local block store
+  err = createBlocks(gen, state)
+  if err != nil {
+    t.Fatal(err)
+  }
+  blockStore := openBlockStore(t, blockStoreDir)
+  // Archive the account states by replaying the blocks from the block store
+  archive, err := chain.OpenArchive(blockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer archive.Close()
+  err = archive.WriteGenesis(gen)
+  if err != nil {
+    t.Fatal(err)
+  }
+  replayState := chain.NewState(gen)
+  for _, number := range []uint64{1, 2} {
+    blk, err := blockStore.Block(number)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = replayState.ApplyBlockToState(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = archive.WriteBlock(blk, replayState)
+    if err != nil {
+      t.Fatal(err)
+    }
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  cases := []struct{
+    name string
+    archive rpc.AccountArchive
+  }{
+    {"reconstruct state", nil},
+    {"archived state", archive},
+  }
+  for _, c := range cases {
+    t.Run(c.name, func(t *testing.T) {
+      // Set up the gRPC server and client
+      conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+        acc := rpc.NewAccountSrv(
+          keyStoreDir, blockStoreDir, blockStore, c.archive, state,
+        )
+        rpc.RegisterAccountServer(grpcSrv, acc)
+      })
+      // Create the gRPC account client
+      cln := rpc.NewAccountClient(conn)
+      // Every block transfers 1 from the initial owner account and contains
+      // one transaction from the initial owner account
+      for number, expBalance := range map[uint64]uint64{
+        1: ownerBalance - 1, 2: ownerBalance - 2,
+      } {
+        // Call the AccountBalance method to get the balance at the block number
+        balReq := &rpc.AccountBalanceReq{
+          Address: string(ownerAcc), Number: number,
+        }
+        balRes, err := cln.AccountBalance(ctx, balReq)
+        if err != nil {
+          t.Fatal(err)
+        }
+        if balRes.Balance != expBalance {
+          t.Errorf(
+            "invalid balance at block %v: expected %v, got %v",
+            number, expBalance, balRes.Balance,
+          )
+        }
+        // Call the AccountNonce method to get the nonce at the block number
+        nonceReq := &rpc.AccountNonceReq{
+          Address: string(ownerAcc), Number: number,
+        }
+        nonceRes, err := cln.AccountNonce(ctx, nonceReq)
+        if err != nil {
+          t.Fatal(err)
+        }
+        if nonceRes.Nonce != number {
+          t.Errorf(
+            "invalid nonce at block %v: expected %v, got %v",
+            number, number, nonceRes.Nonce,
+          )
+        }
+      }
+      // Verify that the balance at a future block is not found
+      req := &rpc.AccountBalanceReq{Address: string(ownerAcc), Number: 10}
+      _, err := cln.AccountBalance(ctx, req)
+      got, exp := status.Code(err), codes.NotFound
+      if got != exp {
+        t.Errorf("wrong error: expected %v, got %v", exp, got)
+      }
+    })
+  }
+}
+
+func historyValues(
+  t *testing.T, res *rpc.AccountHistoryRes,
+) []uint64 {
+  values := make([]uint64, len(res.Txs))
+  for i, jtx := range res.Txs {
+    var tx chain.SearchTx
+    err := json.Unmarshal(jtx, &tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    values[i] = tx.Value
+  }
+  return values
+}
+
+func TestAccountHistory(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state from the genesis
+  state := chain.NewState(gen)
+  // Create several confirmed blocks on the state and on the local block store
+  err = createBlocks(gen, state)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Set up the gRPC server and client
+  conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+    acc := rpc.NewAccountSrv(
+      keyStoreDir, blockStoreDir, openBlockStore(t, blockStoreDir), nil, state,
+    )
+    rpc.RegisterAccountServer(grpcSrv, acc)
+  })
+  // Create the gRPC account client
+  cln := rpc.NewAccountClient(conn)
+  ownerAcc, _ := genesisAccount(gen)
+  t.Run("paginate history", func(t *testing.T) {
+    // Call the AccountHistory method to get the first page of the history
+    req := &rpc.AccountHistoryReq{Address: string(ownerAcc), Limit: 3}
+    res, err := cln.AccountHistory(ctx, req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if len(res.Txs) != 3 || len(res.Cursor) == 0 {
+      t.Fatalf(
+        "invalid first page: expected 3 txs and cursor, got %v txs, cursor %q",
+        len(res.Txs), res.Cursor,
+      )
+    }
+    values := historyValues(t, res)
+    // Call the AccountHistory method with the cursor to get the next page of
+    // the history
+    req.Cursor = res.Cursor
+    res, err = cln.AccountHistory(ctx, req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if len(res.Txs) != 1 || len(res.Cursor) != 0 {
+      t.Fatalf(
+        "invalid last page: expected 1 tx, got %v txs, cursor %q",
+        len(res.Txs), res.Cursor,
+      )
+    }
+    // Verify that the pages contain all incoming and outgoing txs of the
+    // initial owner account ordered by block
+    values = append(values, historyValues(t, res)...)
+    var sum uint64
+    for _, value := range values {
+      sum += value
+    }
+    if sum != 1 + 2 + 3 + 4 || max(values[0], values[1]) > 2 {
+      t.Errorf("invalid history: got %v", values)
+    }
+  })
+  t.Run("filter by direction and block range", func(t *testing.T) {
+    cases := []struct{
+      name, direction string
+      fromBlock, toBlock uint64
+      expValues []uint64
+    }{
+      {"outgoing", "out", 0, 0, []uint64{2, 4}},
+      {"incoming", "in", 0, 0, []uint64{1, 3}},
+      {"block range", "", 2, 2, []uint64{3, 4}},
+      {"outgoing block range", "out", 0, 1, []uint64{2}},
+    }
+    for _, c := range cases {
+      req := &rpc.AccountHistoryReq{
+        Address: string(ownerAcc), Direction: c.direction,
+        FromBlock: c.fromBlock, ToBlock: c.toBlock,
+      }
+      res, err := cln.AccountHistory(ctx, req)
+      if err != nil {
+        t.Fatal(err)
+      }
+      values := historyValues(t, res)
+      slices.Sort(values)
+      if !slices.Equal(values, c.expValues) {
+        t.Errorf(
+          "invalid %v history: expected %v, got %v",
+          c.name, c.expValues, values,
+        )
+      }
+    }
+  })
+  t.Run("reject invalid cursor", func(t *testing.T) {
+    req := &rpc.AccountHistoryReq{Address: string(ownerAcc), Cursor: "x"}
+    _, err := cln.AccountHistory(ctx, req)
+    got, exp := status.Code(err), codes.InvalidArgument
+    if got != exp {
+      t.Errorf("wrong error: expected %v, got %v", exp, got)
+    }
+  })
+}
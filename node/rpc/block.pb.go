@@ -394,6 +394,295 @@ func (x *BlockSearchRes) GetBlock() []byte {
 	return nil
 }
 
+type BlockRangeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From  uint64 `protobuf:"varint,1,opt,name=From,proto3" json:"From,omitempty"`
+	To    uint64 `protobuf:"varint,2,opt,name=To,proto3" json:"To,omitempty"`
+	Limit uint32 `protobuf:"varint,3,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (x *BlockRangeReq) Reset() {
+	*x = BlockRangeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockRangeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRangeReq) ProtoMessage() {}
+
+func (x *BlockRangeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRangeReq.ProtoReflect.Descriptor instead.
+func (*BlockRangeReq) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlockRangeReq) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *BlockRangeReq) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *BlockRangeReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BlockRangeRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block []byte `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+}
+
+func (x *BlockRangeRes) Reset() {
+	*x = BlockRangeRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockRangeRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRangeRes) ProtoMessage() {}
+
+func (x *BlockRangeRes) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRangeRes.ProtoReflect.Descriptor instead.
+func (*BlockRangeRes) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BlockRangeRes) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type BlockLatestReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BlockLatestReq) Reset() {
+	*x = BlockLatestReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockLatestReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockLatestReq) ProtoMessage() {}
+
+func (x *BlockLatestReq) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockLatestReq.ProtoReflect.Descriptor instead.
+func (*BlockLatestReq) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{10}
+}
+
+type BlockLatestRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block []byte `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+}
+
+func (x *BlockLatestRes) Reset() {
+	*x = BlockLatestRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockLatestRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockLatestRes) ProtoMessage() {}
+
+func (x *BlockLatestRes) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockLatestRes.ProtoReflect.Descriptor instead.
+func (*BlockLatestRes) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BlockLatestRes) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type SnapshotSyncReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint64 `protobuf:"varint,1,opt,name=Number,proto3" json:"Number,omitempty"`
+}
+
+func (x *SnapshotSyncReq) Reset() {
+	*x = SnapshotSyncReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotSyncReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotSyncReq) ProtoMessage() {}
+
+func (x *SnapshotSyncReq) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotSyncReq.ProtoReflect.Descriptor instead.
+func (*SnapshotSyncReq) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SnapshotSyncReq) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type SnapshotSyncRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chunk []byte `protobuf:"bytes,1,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
+}
+
+func (x *SnapshotSyncRes) Reset() {
+	*x = SnapshotSyncRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotSyncRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotSyncRes) ProtoMessage() {}
+
+func (x *SnapshotSyncRes) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotSyncRes.ProtoReflect.Descriptor instead.
+func (*SnapshotSyncRes) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SnapshotSyncRes) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
 var File_block_proto protoreflect.FileDescriptor
 
 var file_block_proto_rawDesc = []byte{
@@ -418,7 +707,23 @@ var file_block_proto_rawDesc = []byte{
 	0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x26, 0x0a, 0x0e, 0x42,
 	0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x12, 0x14, 0x0a,
 	0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x32, 0xce, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2f, 0x0a,
+	0x6f, 0x63, 0x6b, 0x22, 0x49, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x25,
+	0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x10, 0x0a, 0x0e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x22, 0x26, 0x0a, 0x0e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22,
+	0x29, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52,
+	0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x27, 0x0a, 0x0f, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x32, 0xe5, 0x02, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2f, 0x0a,
 	0x0b, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x0f, 0x2e, 0x47,
 	0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e,
 	0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x12, 0x2b,
@@ -431,8 +736,17 @@ var file_block_proto_rawDesc = []byte{
 	0x01, 0x12, 0x31, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
 	0x12, 0x0f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
 	0x71, 0x1a, 0x0f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
-	0x65, 0x73, 0x30, 0x01, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x30, 0x01, 0x12, 0x2e, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x0e, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x30, 0x01, 0x12, 0x2f, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x74,
+	0x65, 0x73, 0x74, 0x12, 0x0f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x74, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x74, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x10, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x30, 0x01, 0x42, 0x07, 0x5a, 0x05, 0x2e,
+	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -447,7 +761,7 @@ func file_block_proto_rawDescGZIP() []byte {
 	return file_block_proto_rawDescData
 }
 
-var file_block_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_block_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_block_proto_goTypes = []any{
 	(*GenesisSyncReq)(nil),  // 0: GenesisSyncReq
 	(*GenesisSyncRes)(nil),  // 1: GenesisSyncRes
@@ -457,21 +771,33 @@ var file_block_proto_goTypes = []any{
 	(*BlockReceiveRes)(nil), // 5: BlockReceiveRes
 	(*BlockSearchReq)(nil),  // 6: BlockSearchReq
 	(*BlockSearchRes)(nil),  // 7: BlockSearchRes
+	(*BlockRangeReq)(nil),   // 8: BlockRangeReq
+	(*BlockRangeRes)(nil),   // 9: BlockRangeRes
+	(*BlockLatestReq)(nil),  // 10: BlockLatestReq
+	(*BlockLatestRes)(nil),  // 11: BlockLatestRes
+	(*SnapshotSyncReq)(nil), // 12: SnapshotSyncReq
+	(*SnapshotSyncRes)(nil), // 13: SnapshotSyncRes
 }
 var file_block_proto_depIdxs = []int32{
-	0, // 0: Block.GenesisSync:input_type -> GenesisSyncReq
-	2, // 1: Block.BlockSync:input_type -> BlockSyncReq
-	4, // 2: Block.BlockReceive:input_type -> BlockReceiveReq
-	6, // 3: Block.BlockSearch:input_type -> BlockSearchReq
-	1, // 4: Block.GenesisSync:output_type -> GenesisSyncRes
-	3, // 5: Block.BlockSync:output_type -> BlockSyncRes
-	5, // 6: Block.BlockReceive:output_type -> BlockReceiveRes
-	7, // 7: Block.BlockSearch:output_type -> BlockSearchRes
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: Block.GenesisSync:input_type -> GenesisSyncReq
+	2,  // 1: Block.BlockSync:input_type -> BlockSyncReq
+	4,  // 2: Block.BlockReceive:input_type -> BlockReceiveReq
+	6,  // 3: Block.BlockSearch:input_type -> BlockSearchReq
+	8,  // 4: Block.BlockRange:input_type -> BlockRangeReq
+	10, // 5: Block.BlockLatest:input_type -> BlockLatestReq
+	12, // 6: Block.SnapshotSync:input_type -> SnapshotSyncReq
+	1,  // 7: Block.GenesisSync:output_type -> GenesisSyncRes
+	3,  // 8: Block.BlockSync:output_type -> BlockSyncRes
+	5,  // 9: Block.BlockReceive:output_type -> BlockReceiveRes
+	7,  // 10: Block.BlockSearch:output_type -> BlockSearchRes
+	9,  // 11: Block.BlockRange:output_type -> BlockRangeRes
+	11, // 12: Block.BlockLatest:output_type -> BlockLatestRes
+	13, // 13: Block.SnapshotSync:output_type -> SnapshotSyncRes
+	7,  // [7:14] is the sub-list for method output_type
+	0,  // [0:7] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_block_proto_init() }
@@ -576,6 +902,78 @@ func file_block_proto_init() {
 				return nil
 			}
 		}
+		file_block_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockRangeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_block_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockRangeRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_block_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockLatestReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_block_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockLatestRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_block_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotSyncReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_block_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotSyncRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -583,7 +981,7 @@ func file_block_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_block_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,9 @@ const (
 	Block_BlockSync_FullMethodName    = "/Block/BlockSync"
 	Block_BlockReceive_FullMethodName = "/Block/BlockReceive"
 	Block_BlockSearch_FullMethodName  = "/Block/BlockSearch"
+	Block_BlockRange_FullMethodName   = "/Block/BlockRange"
+	Block_BlockLatest_FullMethodName  = "/Block/BlockLatest"
+	Block_SnapshotSync_FullMethodName = "/Block/SnapshotSync"
 )
 
 // BlockClient is the client API for Block service.
@@ -33,6 +36,9 @@ type BlockClient interface {
 	BlockSync(ctx context.Context, in *BlockSyncReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockSyncRes], error)
 	BlockReceive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BlockReceiveReq, BlockReceiveRes], error)
 	BlockSearch(ctx context.Context, in *BlockSearchReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockSearchRes], error)
+	BlockRange(ctx context.Context, in *BlockRangeReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockRangeRes], error)
+	BlockLatest(ctx context.Context, in *BlockLatestReq, opts ...grpc.CallOption) (*BlockLatestRes, error)
+	SnapshotSync(ctx context.Context, in *SnapshotSyncReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotSyncRes], error)
 }
 
 type blockClient struct {
@@ -104,6 +110,54 @@ func (c *blockClient) BlockSearch(ctx context.Context, in *BlockSearchReq, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Block_BlockSearchClient = grpc.ServerStreamingClient[BlockSearchRes]
 
+func (c *blockClient) BlockRange(ctx context.Context, in *BlockRangeReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockRangeRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Block_ServiceDesc.Streams[3], Block_BlockRange_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BlockRangeReq, BlockRangeRes]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Block_BlockRangeClient = grpc.ServerStreamingClient[BlockRangeRes]
+
+func (c *blockClient) BlockLatest(ctx context.Context, in *BlockLatestReq, opts ...grpc.CallOption) (*BlockLatestRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockLatestRes)
+	err := c.cc.Invoke(ctx, Block_BlockLatest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockClient) SnapshotSync(ctx context.Context, in *SnapshotSyncReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotSyncRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Block_ServiceDesc.Streams[4], Block_SnapshotSync_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SnapshotSyncReq, SnapshotSyncRes]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Block_SnapshotSyncClient = grpc.ServerStreamingClient[SnapshotSyncRes]
+
 // BlockServer is the server API for Block service.
 // All implementations must embed UnimplementedBlockServer
 // for forward compatibility.
@@ -112,6 +166,9 @@ type BlockServer interface {
 	BlockSync(*BlockSyncReq, grpc.ServerStreamingServer[BlockSyncRes]) error
 	BlockReceive(grpc.ClientStreamingServer[BlockReceiveReq, BlockReceiveRes]) error
 	BlockSearch(*BlockSearchReq, grpc.ServerStreamingServer[BlockSearchRes]) error
+	BlockRange(*BlockRangeReq, grpc.ServerStreamingServer[BlockRangeRes]) error
+	BlockLatest(context.Context, *BlockLatestReq) (*BlockLatestRes, error)
+	SnapshotSync(*SnapshotSyncReq, grpc.ServerStreamingServer[SnapshotSyncRes]) error
 	mustEmbedUnimplementedBlockServer()
 }
 
@@ -134,6 +191,15 @@ func (UnimplementedBlockServer) BlockReceive(grpc.ClientStreamingServer[BlockRec
 func (UnimplementedBlockServer) BlockSearch(*BlockSearchReq, grpc.ServerStreamingServer[BlockSearchRes]) error {
 	return status.Errorf(codes.Unimplemented, "method BlockSearch not implemented")
 }
+func (UnimplementedBlockServer) BlockRange(*BlockRangeReq, grpc.ServerStreamingServer[BlockRangeRes]) error {
+	return status.Errorf(codes.Unimplemented, "method BlockRange not implemented")
+}
+func (UnimplementedBlockServer) BlockLatest(context.Context, *BlockLatestReq) (*BlockLatestRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockLatest not implemented")
+}
+func (UnimplementedBlockServer) SnapshotSync(*SnapshotSyncReq, grpc.ServerStreamingServer[SnapshotSyncRes]) error {
+	return status.Errorf(codes.Unimplemented, "method SnapshotSync not implemented")
+}
 func (UnimplementedBlockServer) mustEmbedUnimplementedBlockServer() {}
 func (UnimplementedBlockServer) testEmbeddedByValue()               {}
 
@@ -202,6 +268,46 @@ func _Block_BlockSearch_Handler(srv interface{}, stream grpc.ServerStream) error
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Block_BlockSearchServer = grpc.ServerStreamingServer[BlockSearchRes]
 
+func _Block_BlockRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockRangeReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockServer).BlockRange(m, &grpc.GenericServerStream[BlockRangeReq, BlockRangeRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Block_BlockRangeServer = grpc.ServerStreamingServer[BlockRangeRes]
+
+func _Block_BlockLatest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockLatestReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServer).BlockLatest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Block_BlockLatest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServer).BlockLatest(ctx, req.(*BlockLatestReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Block_SnapshotSync_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotSyncReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockServer).SnapshotSync(m, &grpc.GenericServerStream[SnapshotSyncReq, SnapshotSyncRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Block_SnapshotSyncServer = grpc.ServerStreamingServer[SnapshotSyncRes]
+
 // Block_ServiceDesc is the grpc.ServiceDesc for Block service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -213,6 +319,10 @@ var Block_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenesisSync",
 			Handler:    _Block_GenesisSync_Handler,
 		},
+		{
+			MethodName: "BlockLatest",
+			Handler:    _Block_BlockLatest_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -230,6 +340,16 @@ var Block_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Block_BlockSearch_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BlockRange",
+			Handler:       _Block_BlockRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SnapshotSync",
+			Handler:       _Block_SnapshotSync_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "block.proto",
 }
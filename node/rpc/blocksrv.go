@@ -17,6 +17,13 @@ type BlockApplier interface {
   ApplyBlockToState(blk chain.SigBlock) error
 }
 
+// ForkApplier applies the received block following the fork choice rule. On
+// the chain reorg the reverted blocks of the old main chain and the applied
+// blocks of the new main chain are returned
+type ForkApplier interface {
+  ApplyForkBlock(blk chain.SigBlock) ([]chain.SigBlock, []chain.SigBlock, error)
+}
+
 type BlockRelayer interface {
   RelayBlock(blk chain.SigBlock)
 }
@@ -24,17 +31,19 @@ type BlockRelayer interface {
 type BlockSrv struct {
   UnimplementedBlockServer
   blockStoreDir string
+  blockStore chain.BlockStore
   eventPub chain.EventPublisher
   blkApplier BlockApplier
   blkRelayer BlockRelayer
 }
 
 func NewBlockSrv(
-  blockStoreDir string, eventPub chain.EventPublisher,
+  blockStoreDir string, blockStore chain.BlockStore,
+  eventPub chain.EventPublisher,
   blkApplier BlockApplier, blkRelayer BlockRelayer,
 ) *BlockSrv {
   return &BlockSrv{
-    blockStoreDir: blockStoreDir, eventPub: eventPub,
+    blockStoreDir: blockStoreDir, blockStore: blockStore, eventPub: eventPub,
     blkApplier: blkApplier, blkRelayer: blkRelayer,
   }
 }
@@ -53,24 +62,41 @@ func (s *BlockSrv) GenesisSync(
 func (s *BlockSrv) BlockSync(
   req *BlockSyncReq, stream grpc.ServerStreamingServer[BlockSyncRes],
 ) error {
-  blocks, closeBlocks, err := chain.ReadBlocksBytes(s.blockStoreDir)
+  blocks, closeBlocks, err := s.blockStore.BlocksBytes(req.Number)
   if err != nil {
     return status.Errorf(codes.NotFound, err.Error())
   }
   defer closeBlocks()
-  num, i := int(req.Number), 1
   for err, jblk := range blocks {
     if err != nil {
       return status.Errorf(codes.Internal, err.Error())
     }
-    if i >= num {
-      res := &BlockSyncRes{Block: jblk}
-      err = stream.Send(res)
-      if err != nil {
-        return status.Errorf(codes.Internal, err.Error())
-      }
+    res := &BlockSyncRes{Block: jblk}
+    err = stream.Send(res)
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
+    }
+  }
+  return nil
+}
+
+const snapshotChunkSize = 64 * 1024
+
+func (s *BlockSrv) SnapshotSync(
+  req *SnapshotSyncReq, stream grpc.ServerStreamingServer[SnapshotSyncRes],
+) error {
+  jsnap, err := chain.ReadSnapshotBytes(s.blockStoreDir, req.Number)
+  if err != nil {
+    return status.Errorf(codes.NotFound, err.Error())
+  }
+  for len(jsnap) > 0 {
+    chunk := jsnap[:min(snapshotChunkSize, len(jsnap))]
+    jsnap = jsnap[len(chunk):]
+    res := &SnapshotSyncRes{Chunk: chunk}
+    err = stream.Send(res)
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
     }
-    i++
   }
   return nil
 }
@@ -105,52 +131,166 @@ func (s *BlockSrv) BlockReceive(
       continue
     }
     fmt.Printf("<== Block receive\n%v", blk)
-    err = s.blkApplier.ApplyBlockToState(blk)
+    reverted, applied, err := s.applyBlock(blk)
     if err != nil {
       fmt.Print(err)
       continue
     }
-    err = blk.Write(s.blockStoreDir)
-    if err != nil {
-      fmt.Println(err)
-      continue
-    }
     if s.blkRelayer != nil {
       s.blkRelayer.RelayBlock(blk)
     }
     if s.eventPub != nil {
-      s.publishBlockAndTxs(blk)
+      for i := len(reverted) - 1; i >= 0; i-- {
+        jblk, _ := json.Marshal(reverted[i])
+        event := chain.NewEvent(chain.EvBlock, "reverted", jblk)
+        s.eventPub.PublishEvent(event)
+      }
+      for _, blk := range applied {
+        s.publishBlockAndTxs(blk)
+      }
+    }
+  }
+}
+
+// applyBlock applies the block following the fork choice rule when the block
+// applier supports forks. Otherwise, only the block extending the main chain
+// is applied to the state and the block store
+func (s *BlockSrv) applyBlock(blk chain.SigBlock) (
+  []chain.SigBlock, []chain.SigBlock, error,
+) {
+  forkApplier, ok := s.blkApplier.(ForkApplier)
+  if ok {
+    return forkApplier.ApplyForkBlock(blk)
+  }
+  err := s.blkApplier.ApplyBlockToState(blk)
+  if err != nil {
+    return nil, nil, err
+  }
+  err = s.blockStore.WriteBlock(blk)
+  if err != nil {
+    return nil, nil, err
+  }
+  return nil, []chain.SigBlock{blk}, nil
+}
+
+func (s *BlockSrv) searchBlock(req *BlockSearchReq) (uint64, bool, error) {
+  switch {
+  case req.Number != 0:
+    return req.Number, true, nil
+  case len(req.Hash) > 0:
+    return s.blockStore.FindBlock(req.Hash)
+  case len(req.Parent) > 0:
+    gen, err := chain.ReadGenesis(s.blockStoreDir)
+    if err != nil {
+      return 0, false, err
+    }
+    if strings.HasPrefix(gen.Hash().String(), strings.ToLower(req.Parent)) {
+      return 1, true, nil
     }
+    number, found, err := s.blockStore.FindBlock(req.Parent)
+    return number + 1, found, err
+  default:
+    return 0, false, nil
   }
 }
 
 func (s *BlockSrv) BlockSearch(
   req *BlockSearchReq, stream grpc.ServerStreamingServer[BlockSearchRes],
 ) error {
-  blocks, closeBlocks, err := chain.ReadBlocks(s.blockStoreDir)
+  number, found, err := s.searchBlock(req)
+  if err != nil {
+    return status.Errorf(codes.Internal, err.Error())
+  }
+  height, err := s.blockStore.Height()
+  if err != nil {
+    return status.Errorf(codes.Internal, err.Error())
+  }
+  if !found || number > height {
+    return nil
+  }
+  jblk, err := s.blockStore.BlockBytes(number)
+  if err != nil {
+    return status.Errorf(codes.Internal, err.Error())
+  }
+  res := &BlockSearchRes{Block: jblk}
+  err = stream.Send(res)
+  if err != nil {
+    return status.Errorf(codes.Internal, err.Error())
+  }
+  return nil
+}
+
+const (
+  blockRangeLimit = 100
+  blockRangeMaxLimit = 1000
+)
+
+// BlockRange streams the confirmed blocks from the first block number to the
+// last block number inclusive. The zero last block number means the last
+// confirmed block
+func (s *BlockSrv) BlockRange(
+  req *BlockRangeReq, stream grpc.ServerStreamingServer[BlockRangeRes],
+) error {
+  from, to := max(req.From, 1), req.To
+  if to != 0 && to < from {
+    return status.Errorf(
+      codes.InvalidArgument,
+      fmt.Sprintf("invalid block range: %v > %v", from, to),
+    )
+  }
+  height, err := s.blockStore.Height()
+  if err != nil {
+    return status.Errorf(codes.Internal, err.Error())
+  }
+  if to == 0 || to > height {
+    to = height
+  }
+  limit := uint64(req.Limit)
+  if limit == 0 {
+    limit = blockRangeLimit
+  }
+  limit = min(limit, blockRangeMaxLimit)
+  if from > to {
+    return nil
+  }
+  to = min(to, from + limit - 1)
+  blocks, closeBlocks, err := s.blockStore.BlocksBytes(from)
   if err != nil {
     return status.Errorf(codes.NotFound, err.Error())
   }
   defer closeBlocks()
-  prefix := strings.HasPrefix
-  for err, blk := range blocks {
+  number := from
+  for err, jblk := range blocks {
     if err != nil {
       return status.Errorf(codes.Internal, err.Error())
     }
-    if req.Number != 0 && blk.Number == req.Number ||
-      len(req.Hash) > 0 && prefix(blk.Hash().String(), req.Hash) ||
-      len(req.Parent) > 0 && prefix(blk.Parent.String(), req.Parent) {
-      jblk, err := json.Marshal(blk)
-      if err != nil {
-        return status.Errorf(codes.Internal, err.Error())
-      }
-      res := &BlockSearchRes{Block: jblk}
-      err = stream.Send(res)
-      if err != nil {
-        return status.Errorf(codes.Internal, err.Error())
-      }
+    res := &BlockRangeRes{Block: jblk}
+    err = stream.Send(res)
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
+    }
+    if number == to {
       break
     }
+    number++
   }
   return nil
 }
+
+func (s *BlockSrv) BlockLatest(
+  _ context.Context, req *BlockLatestReq,
+) (*BlockLatestRes, error) {
+  height, err := s.blockStore.Height()
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  if height == 0 {
+    return nil, status.Errorf(codes.NotFound, "no confirmed blocks")
+  }
+  jblk, err := s.blockStore.BlockBytes(height)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &BlockLatestRes{Block: jblk}
+  return res, nil
+}
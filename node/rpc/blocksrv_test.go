@@ -6,18 +6,21 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
 	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func createTxs(acc chain.Account, values []uint64, pending *chain.State) error {
   for _, value := range values {
     tx := chain.NewTx(
-      acc.Address(), chain.Address("to"), value,
+      chainName, acc.Address(), chain.Address("to"), value,
       pending.Nonce(acc.Address()) + 1,
     )
     stx, err := acc.SignTx(tx)
@@ -98,7 +101,7 @@ func createBlocks(gen chain.SigGenesis, state *chain.State) error {
   for _, txs := range blocks {
     for _, t := range txs {
       tx := chain.NewTx(
-        t.from.Address(), t.to.Address(), t.value,
+        chainName, t.from.Address(), t.to.Address(), t.value,
         state.Pending.Nonce(t.from.Address()) + 1,
       )
       stx, err := t.from.SignTx(tx)
@@ -111,7 +114,7 @@ func createBlocks(gen chain.SigGenesis, state *chain.State) error {
       }
     }
     clone := state.Clone()
-    blk, err := clone.CreateBlock(auth)
+    blk, err := clone.CreateBlock(auth, 0)
     if err != nil {
       return err
     }
@@ -143,7 +146,10 @@ func TestGenesisSync(t *testing.T) {
   state := chain.NewState(gen)
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    blk := rpc.NewBlockSrv(blockStoreDir, nil, state, nil)
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir),
+      nil, state, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Create the gRPC block client
@@ -190,7 +196,10 @@ func TestBlockSync(t *testing.T) {
   }
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    blk := rpc.NewBlockSrv(blockStoreDir, nil, state, nil)
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir),
+      nil, state, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Create the gRPC block client
@@ -219,7 +228,7 @@ func TestBlockSync(t *testing.T) {
       t.Fatal(err)
     }
     // Verify that the signature of the received block is valid
-    valid, err := chain.VerifyBlock(blk, state.Authority())
+    valid, err := chain.VerifyBlock(blk, state.Validators())
     if err != nil {
       t.Fatal(err)
     }
@@ -278,13 +287,16 @@ func TestBlockReceive(t *testing.T) {
   }
   // Create a new block on the cloned state
   clone := state.Clone()
-  blk, err := clone.CreateBlock(auth)
+  blk, err := clone.CreateBlock(auth, 0)
   if err != nil {
     t.Fatal(err)
   }
   // Set up the gRPC server and gRPC client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    blk := rpc.NewBlockSrv(blockStoreDir, nil, state, nil)
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir),
+      nil, state, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Create the gRPC block client
@@ -343,7 +355,10 @@ func TestBlockSearch(t *testing.T) {
   }
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    blk := rpc.NewBlockSrv(blockStoreDir, nil, state, nil)
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir),
+      nil, state, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   var hash, parent chain.Hash
@@ -400,3 +415,190 @@ func TestBlockSearch(t *testing.T) {
     }
   })
 }
+
+func TestSnapshotSync(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state from the genesis
+  state := chain.NewState(gen)
+  // Create several confirmed blocks on the state and on the local block store
+  err = createBlocks(gen, state)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Persist the state snapshot on the local block store
+  expSnap := state.Snapshot()
+  err = expSnap.Write(blockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Set up the gRPC server and client
+  conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir),
+      nil, state, nil,
+    )
+    rpc.RegisterBlockServer(grpcSrv, blk)
+  })
+  // Create the gRPC block client
+  cln := rpc.NewBlockClient(conn)
+  // Call the SnapshotSync method to get the gRPC server stream of the latest
+  // snapshot chunks
+  req := &rpc.SnapshotSyncReq{}
+  stream, err := cln.SnapshotSync(ctx, req)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Reassemble the snapshot from the received chunks
+  var jsnap []byte
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      t.Fatal(err)
+    }
+    jsnap = append(jsnap, res.Chunk...)
+  }
+  // Decode the reassembled snapshot
+  var gotSnap chain.SealSnapshot
+  err = json.Unmarshal(jsnap, &gotSnap)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the received snapshot is equal to the persisted snapshot
+  if gotSnap.Seal != expSnap.Seal || gotSnap.Snapshot.Hash() != gotSnap.Seal {
+    t.Errorf("invalid snapshot seal")
+  }
+  if gotSnap.LastBlock.Number != state.LastBlock().Number {
+    t.Errorf(
+      "invalid snapshot block: expected %v, got %v",
+      state.LastBlock().Number, gotSnap.LastBlock.Number,
+    )
+  }
+}
+
+func rangeBlocks(
+  t *testing.T, ctx context.Context,
+  conn grpc.ClientConnInterface, req *rpc.BlockRangeReq,
+) ([]uint64, error) {
+  // Create the gRPC block client
+  cln := rpc.NewBlockClient(conn)
+  // Call the BlockRange method to get the gRPC server stream of blocks in the
+  // range
+  stream, err := cln.BlockRange(ctx, req)
+  if err != nil {
+    t.Fatal(err)
+  }
+  numbers := make([]uint64, 0)
+  for {
+    // Receive a block from the server stream
+    res, err := stream.Recv()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return nil, err
+    }
+    // Decode the received block
+    var blk chain.SigBlock
+    err = json.Unmarshal(res.Block, &blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    numbers = append(numbers, blk.Number)
+  }
+  return numbers, nil
+}
+
+func TestBlockRangeLatest(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create and persist the genesis
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the state from the genesis
+  state := chain.NewState(gen)
+  // Create several confirmed blocks on the state and on the local block store
+  for range 2 {
+    err = createBlocks(gen, state)
+    if err != nil {
+      t.Fatal(err)
+    }
+  }
+  // Set up the gRPC server and client
+  conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+    blk := rpc.NewBlockSrv(
+      blockStoreDir, openBlockStore(t, blockStoreDir),
+      nil, state, nil,
+    )
+    rpc.RegisterBlockServer(grpcSrv, blk)
+  })
+  t.Run("block range", func(t *testing.T) {
+    cases := []struct{
+      name string
+      from, to uint64
+      limit uint32
+      expNumbers []uint64
+    }{
+      {"all blocks", 0, 0, 0, []uint64{1, 2, 3, 4}},
+      {"closed range", 2, 3, 0, []uint64{2, 3}},
+      {"limited range", 2, 0, 1, []uint64{2}},
+      {"range past the last block", 3, 10, 0, []uint64{3, 4}},
+      {"range after the last block", 5, 0, 0, []uint64{}},
+    }
+    for _, c := range cases {
+      // Request the blocks in the range of block numbers
+      req := &rpc.BlockRangeReq{From: c.from, To: c.to, Limit: c.limit}
+      numbers, err := rangeBlocks(t, ctx, conn, req)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that the blocks in the range are returned in order
+      if !slices.Equal(numbers, c.expNumbers) {
+        t.Errorf(
+          "invalid %v: expected %v, got %v", c.name, c.expNumbers, numbers,
+        )
+      }
+    }
+    // Verify that the inverted range is rejected
+    req := &rpc.BlockRangeReq{From: 3, To: 2}
+    _, err := rangeBlocks(t, ctx, conn, req)
+    got, exp := status.Code(err), codes.InvalidArgument
+    if got != exp {
+      t.Errorf("wrong error: expected %v, got %v", exp, got)
+    }
+  })
+  t.Run("latest block", func(t *testing.T) {
+    // Request the last confirmed block
+    cln := rpc.NewBlockClient(conn)
+    res, err := cln.BlockLatest(ctx, &rpc.BlockLatestReq{})
+    if err != nil {
+      t.Fatal(err)
+    }
+    var blk chain.SigBlock
+    err = json.Unmarshal(res.Block, &blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the last confirmed block is the last block of the state
+    if blk.Hash() != state.LastBlock().Hash() {
+      t.Errorf(
+        "invalid latest block: expected %v, got %v",
+        state.LastBlock().Number, blk.Number,
+      )
+    }
+  })
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: consensus.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConsensusReceiveReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg []byte `protobuf:"bytes,1,opt,name=Msg,proto3" json:"Msg,omitempty"`
+}
+
+func (x *ConsensusReceiveReq) Reset() {
+	*x = ConsensusReceiveReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusReceiveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusReceiveReq) ProtoMessage() {}
+
+func (x *ConsensusReceiveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusReceiveReq.ProtoReflect.Descriptor instead.
+func (*ConsensusReceiveReq) Descriptor() ([]byte, []int) {
+	return file_consensus_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConsensusReceiveReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type ConsensusReceiveRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ConsensusReceiveRes) Reset() {
+	*x = ConsensusReceiveRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusReceiveRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusReceiveRes) ProtoMessage() {}
+
+func (x *ConsensusReceiveRes) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusReceiveRes.ProtoReflect.Descriptor instead.
+func (*ConsensusReceiveRes) Descriptor() ([]byte, []int) {
+	return file_consensus_proto_rawDescGZIP(), []int{1}
+}
+
+var File_consensus_proto protoreflect.FileDescriptor
+
+var file_consensus_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x27, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x4d, 0x73, 0x67, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x6f,
+	0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65,
+	0x73, 0x32, 0x4d, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x12, 0x40,
+	0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x12, 0x14, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x75, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x28, 0x01,
+	0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_consensus_proto_rawDescOnce sync.Once
+	file_consensus_proto_rawDescData = file_consensus_proto_rawDesc
+)
+
+func file_consensus_proto_rawDescGZIP() []byte {
+	file_consensus_proto_rawDescOnce.Do(func() {
+		file_consensus_proto_rawDescData = protoimpl.X.CompressGZIP(file_consensus_proto_rawDescData)
+	})
+	return file_consensus_proto_rawDescData
+}
+
+var file_consensus_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_consensus_proto_goTypes = []any{
+	(*ConsensusReceiveReq)(nil), // 0: ConsensusReceiveReq
+	(*ConsensusReceiveRes)(nil), // 1: ConsensusReceiveRes
+}
+var file_consensus_proto_depIdxs = []int32{
+	0, // 0: Consensus.ConsensusReceive:input_type -> ConsensusReceiveReq
+	1, // 1: Consensus.ConsensusReceive:output_type -> ConsensusReceiveRes
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_consensus_proto_init() }
+func file_consensus_proto_init() {
+	if File_consensus_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_consensus_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ConsensusReceiveReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ConsensusReceiveRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_consensus_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consensus_proto_goTypes,
+		DependencyIndexes: file_consensus_proto_depIdxs,
+		MessageInfos:      file_consensus_proto_msgTypes,
+	}.Build()
+	File_consensus_proto = out.File
+	file_consensus_proto_rawDesc = nil
+	file_consensus_proto_goTypes = nil
+	file_consensus_proto_depIdxs = nil
+}
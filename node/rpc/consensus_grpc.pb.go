@@ -0,0 +1,114 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.2
+// source: consensus.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Consensus_ConsensusReceive_FullMethodName = "/Consensus/ConsensusReceive"
+)
+
+// ConsensusClient is the client API for Consensus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConsensusClient interface {
+	ConsensusReceive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ConsensusReceiveReq, ConsensusReceiveRes], error)
+}
+
+type consensusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConsensusClient(cc grpc.ClientConnInterface) ConsensusClient {
+	return &consensusClient{cc}
+}
+
+func (c *consensusClient) ConsensusReceive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ConsensusReceiveReq, ConsensusReceiveRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Consensus_ServiceDesc.Streams[0], Consensus_ConsensusReceive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConsensusReceiveReq, ConsensusReceiveRes]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Consensus_ConsensusReceiveClient = grpc.ClientStreamingClient[ConsensusReceiveReq, ConsensusReceiveRes]
+
+// ConsensusServer is the server API for Consensus service.
+// All implementations must embed UnimplementedConsensusServer
+// for forward compatibility.
+type ConsensusServer interface {
+	ConsensusReceive(grpc.ClientStreamingServer[ConsensusReceiveReq, ConsensusReceiveRes]) error
+	mustEmbedUnimplementedConsensusServer()
+}
+
+// UnimplementedConsensusServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConsensusServer struct{}
+
+func (UnimplementedConsensusServer) ConsensusReceive(grpc.ClientStreamingServer[ConsensusReceiveReq, ConsensusReceiveRes]) error {
+	return status.Errorf(codes.Unimplemented, "method ConsensusReceive not implemented")
+}
+func (UnimplementedConsensusServer) mustEmbedUnimplementedConsensusServer() {}
+func (UnimplementedConsensusServer) testEmbeddedByValue()                   {}
+
+// UnsafeConsensusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConsensusServer will
+// result in compilation errors.
+type UnsafeConsensusServer interface {
+	mustEmbedUnimplementedConsensusServer()
+}
+
+func RegisterConsensusServer(s grpc.ServiceRegistrar, srv ConsensusServer) {
+	// If the following call pancis, it indicates UnimplementedConsensusServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Consensus_ServiceDesc, srv)
+}
+
+func _Consensus_ConsensusReceive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConsensusServer).ConsensusReceive(&grpc.GenericServerStream[ConsensusReceiveReq, ConsensusReceiveRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Consensus_ConsensusReceiveServer = grpc.ClientStreamingServer[ConsensusReceiveReq, ConsensusReceiveRes]
+
+// Consensus_ServiceDesc is the grpc.ServiceDesc for Consensus service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Consensus_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Consensus",
+	HandlerType: (*ConsensusServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ConsensusReceive",
+			Handler:       _Consensus_ConsensusReceive_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "consensus.proto",
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ConsensusReceiver interface {
+  ReceiveConsensus(msg chain.ConsensusMsg)
+}
+
+type ConsensusSrv struct {
+  UnimplementedConsensusServer
+  csReceiver ConsensusReceiver
+}
+
+func NewConsensusSrv(csReceiver ConsensusReceiver) *ConsensusSrv {
+  return &ConsensusSrv{csReceiver: csReceiver}
+}
+
+func (s *ConsensusSrv) ConsensusReceive(
+  stream grpc.ClientStreamingServer[ConsensusReceiveReq, ConsensusReceiveRes],
+) error {
+  for {
+    req, err := stream.Recv()
+    if err == io.EOF {
+      res := &ConsensusReceiveRes{}
+      return stream.SendAndClose(res)
+    }
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
+    }
+    var msg chain.ConsensusMsg
+    err = json.Unmarshal(req.Msg, &msg)
+    if err != nil {
+      fmt.Println(err)
+      continue
+    }
+    s.csReceiver.ReceiveConsensus(msg)
+  }
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: evidence.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EvidenceReceiveReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Evidence []byte `protobuf:"bytes,1,opt,name=Evidence,proto3" json:"Evidence,omitempty"`
+}
+
+func (x *EvidenceReceiveReq) Reset() {
+	*x = EvidenceReceiveReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_evidence_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvidenceReceiveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceReceiveReq) ProtoMessage() {}
+
+func (x *EvidenceReceiveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_evidence_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceReceiveReq.ProtoReflect.Descriptor instead.
+func (*EvidenceReceiveReq) Descriptor() ([]byte, []int) {
+	return file_evidence_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvidenceReceiveReq) GetEvidence() []byte {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type EvidenceReceiveRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EvidenceReceiveRes) Reset() {
+	*x = EvidenceReceiveRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_evidence_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvidenceReceiveRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceReceiveRes) ProtoMessage() {}
+
+func (x *EvidenceReceiveRes) ProtoReflect() protoreflect.Message {
+	mi := &file_evidence_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceReceiveRes.ProtoReflect.Descriptor instead.
+func (*EvidenceReceiveRes) Descriptor() ([]byte, []int) {
+	return file_evidence_proto_rawDescGZIP(), []int{1}
+}
+
+var File_evidence_proto protoreflect.FileDescriptor
+
+var file_evidence_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x30, 0x0a, 0x12, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x32, 0x49, 0x0a, 0x08, 0x45, 0x76, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0f, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x13, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x45,
+	0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65,
+	0x73, 0x28, 0x01, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_evidence_proto_rawDescOnce sync.Once
+	file_evidence_proto_rawDescData = file_evidence_proto_rawDesc
+)
+
+func file_evidence_proto_rawDescGZIP() []byte {
+	file_evidence_proto_rawDescOnce.Do(func() {
+		file_evidence_proto_rawDescData = protoimpl.X.CompressGZIP(file_evidence_proto_rawDescData)
+	})
+	return file_evidence_proto_rawDescData
+}
+
+var file_evidence_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_evidence_proto_goTypes = []any{
+	(*EvidenceReceiveReq)(nil), // 0: EvidenceReceiveReq
+	(*EvidenceReceiveRes)(nil), // 1: EvidenceReceiveRes
+}
+var file_evidence_proto_depIdxs = []int32{
+	0, // 0: Evidence.EvidenceReceive:input_type -> EvidenceReceiveReq
+	1, // 1: Evidence.EvidenceReceive:output_type -> EvidenceReceiveRes
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_evidence_proto_init() }
+func file_evidence_proto_init() {
+	if File_evidence_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_evidence_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*EvidenceReceiveReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_evidence_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*EvidenceReceiveRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_evidence_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_evidence_proto_goTypes,
+		DependencyIndexes: file_evidence_proto_depIdxs,
+		MessageInfos:      file_evidence_proto_msgTypes,
+	}.Build()
+	File_evidence_proto = out.File
+	file_evidence_proto_rawDesc = nil
+	file_evidence_proto_goTypes = nil
+	file_evidence_proto_depIdxs = nil
+}
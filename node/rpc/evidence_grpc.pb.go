@@ -0,0 +1,114 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.2
+// source: evidence.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Evidence_EvidenceReceive_FullMethodName = "/Evidence/EvidenceReceive"
+)
+
+// EvidenceClient is the client API for Evidence service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EvidenceClient interface {
+	EvidenceReceive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EvidenceReceiveReq, EvidenceReceiveRes], error)
+}
+
+type evidenceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEvidenceClient(cc grpc.ClientConnInterface) EvidenceClient {
+	return &evidenceClient{cc}
+}
+
+func (c *evidenceClient) EvidenceReceive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EvidenceReceiveReq, EvidenceReceiveRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Evidence_ServiceDesc.Streams[0], Evidence_EvidenceReceive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EvidenceReceiveReq, EvidenceReceiveRes]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Evidence_EvidenceReceiveClient = grpc.ClientStreamingClient[EvidenceReceiveReq, EvidenceReceiveRes]
+
+// EvidenceServer is the server API for Evidence service.
+// All implementations must embed UnimplementedEvidenceServer
+// for forward compatibility.
+type EvidenceServer interface {
+	EvidenceReceive(grpc.ClientStreamingServer[EvidenceReceiveReq, EvidenceReceiveRes]) error
+	mustEmbedUnimplementedEvidenceServer()
+}
+
+// UnimplementedEvidenceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEvidenceServer struct{}
+
+func (UnimplementedEvidenceServer) EvidenceReceive(grpc.ClientStreamingServer[EvidenceReceiveReq, EvidenceReceiveRes]) error {
+	return status.Errorf(codes.Unimplemented, "method EvidenceReceive not implemented")
+}
+func (UnimplementedEvidenceServer) mustEmbedUnimplementedEvidenceServer() {}
+func (UnimplementedEvidenceServer) testEmbeddedByValue()                  {}
+
+// UnsafeEvidenceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EvidenceServer will
+// result in compilation errors.
+type UnsafeEvidenceServer interface {
+	mustEmbedUnimplementedEvidenceServer()
+}
+
+func RegisterEvidenceServer(s grpc.ServiceRegistrar, srv EvidenceServer) {
+	// If the following call pancis, it indicates UnimplementedEvidenceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Evidence_ServiceDesc, srv)
+}
+
+func _Evidence_EvidenceReceive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EvidenceServer).EvidenceReceive(&grpc.GenericServerStream[EvidenceReceiveReq, EvidenceReceiveRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Evidence_EvidenceReceiveServer = grpc.ClientStreamingServer[EvidenceReceiveReq, EvidenceReceiveRes]
+
+// Evidence_ServiceDesc is the grpc.ServiceDesc for Evidence service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Evidence_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Evidence",
+	HandlerType: (*EvidenceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EvidenceReceive",
+			Handler:       _Evidence_EvidenceReceive_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "evidence.proto",
+}
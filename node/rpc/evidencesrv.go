@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EvidenceApplier interface {
+  ApplyEvidence(ev chain.Evidence) error
+}
+
+type EvidenceRelayer interface {
+  RelayEvidence(ev chain.Evidence)
+}
+
+type EvidenceSrv struct {
+  UnimplementedEvidenceServer
+  evApplier EvidenceApplier
+  evRelayer EvidenceRelayer
+}
+
+func NewEvidenceSrv(
+  evApplier EvidenceApplier, evRelayer EvidenceRelayer,
+) *EvidenceSrv {
+  return &EvidenceSrv{evApplier: evApplier, evRelayer: evRelayer}
+}
+
+func (s *EvidenceSrv) EvidenceReceive(
+  stream grpc.ClientStreamingServer[EvidenceReceiveReq, EvidenceReceiveRes],
+) error {
+  for {
+    req, err := stream.Recv()
+    if err == io.EOF {
+      res := &EvidenceReceiveRes{}
+      return stream.SendAndClose(res)
+    }
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
+    }
+    var ev chain.Evidence
+    err = json.Unmarshal(req.Evidence, &ev)
+    if err != nil {
+      fmt.Println(err)
+      continue
+    }
+    fmt.Printf("<== Evidence receive\n%v\n", ev)
+    err = s.evApplier.ApplyEvidence(ev)
+    if err != nil {
+      fmt.Print(err)
+      continue
+    }
+    if s.evRelayer != nil {
+      s.evRelayer.RelayEvidence(ev)
+    }
+  }
+}
@@ -0,0 +1,486 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: mempool.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MempoolListReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
+}
+
+func (x *MempoolListReq) Reset() {
+	*x = MempoolListReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mempool_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MempoolListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolListReq) ProtoMessage() {}
+
+func (x *MempoolListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mempool_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MempoolListReq.ProtoReflect.Descriptor instead.
+func (*MempoolListReq) Descriptor() ([]byte, []int) {
+	return file_mempool_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MempoolListReq) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *MempoolListReq) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type MempoolListRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txs []byte `protobuf:"bytes,1,opt,name=Txs,proto3" json:"Txs,omitempty"`
+}
+
+func (x *MempoolListRes) Reset() {
+	*x = MempoolListRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mempool_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MempoolListRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolListRes) ProtoMessage() {}
+
+func (x *MempoolListRes) ProtoReflect() protoreflect.Message {
+	mi := &file_mempool_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MempoolListRes.ProtoReflect.Descriptor instead.
+func (*MempoolListRes) Descriptor() ([]byte, []int) {
+	return file_mempool_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MempoolListRes) GetTxs() []byte {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+type MempoolGetReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash string `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+}
+
+func (x *MempoolGetReq) Reset() {
+	*x = MempoolGetReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mempool_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MempoolGetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolGetReq) ProtoMessage() {}
+
+func (x *MempoolGetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mempool_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MempoolGetReq.ProtoReflect.Descriptor instead.
+func (*MempoolGetReq) Descriptor() ([]byte, []int) {
+	return file_mempool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MempoolGetReq) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type MempoolGetRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx []byte `protobuf:"bytes,1,opt,name=Tx,proto3" json:"Tx,omitempty"`
+}
+
+func (x *MempoolGetRes) Reset() {
+	*x = MempoolGetRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mempool_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MempoolGetRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolGetRes) ProtoMessage() {}
+
+func (x *MempoolGetRes) ProtoReflect() protoreflect.Message {
+	mi := &file_mempool_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MempoolGetRes.ProtoReflect.Descriptor instead.
+func (*MempoolGetRes) Descriptor() ([]byte, []int) {
+	return file_mempool_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MempoolGetRes) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type MempoolStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MempoolStatsReq) Reset() {
+	*x = MempoolStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mempool_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MempoolStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolStatsReq) ProtoMessage() {}
+
+func (x *MempoolStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mempool_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MempoolStatsReq.ProtoReflect.Descriptor instead.
+func (*MempoolStatsReq) Descriptor() ([]byte, []int) {
+	return file_mempool_proto_rawDescGZIP(), []int{4}
+}
+
+type MempoolStatsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count    uint64 `protobuf:"varint,1,opt,name=Count,proto3" json:"Count,omitempty"`
+	Bytes    uint64 `protobuf:"varint,2,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	Accounts []byte `protobuf:"bytes,3,opt,name=Accounts,proto3" json:"Accounts,omitempty"`
+}
+
+func (x *MempoolStatsRes) Reset() {
+	*x = MempoolStatsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mempool_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MempoolStatsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolStatsRes) ProtoMessage() {}
+
+func (x *MempoolStatsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_mempool_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MempoolStatsRes.ProtoReflect.Descriptor instead.
+func (*MempoolStatsRes) Descriptor() ([]byte, []int) {
+	return file_mempool_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MempoolStatsRes) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MempoolStatsRes) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *MempoolStatsRes) GetAccounts() []byte {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+var File_mempool_proto protoreflect.FileDescriptor
+
+var file_mempool_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x6d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x34, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x54, 0x6f, 0x22, 0x22, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x78, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x54, 0x78, 0x73, 0x22, 0x23, 0x0a, 0x0d, 0x4d, 0x65, 0x6d,
+	0x70, 0x6f, 0x6f, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x1f,
+	0x0a, 0x0d, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x54, 0x78, 0x22,
+	0x11, 0x0a, 0x0f, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x22, 0x59, 0x0a, 0x0f, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x32, 0x9c, 0x01,
+	0x0a, 0x07, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x2f, 0x0a, 0x0b, 0x4d, 0x65, 0x6d,
+	0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0f, 0x2e, 0x4d, 0x65, 0x6d, 0x70, 0x6f,
+	0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x4d, 0x65, 0x6d, 0x70,
+	0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x0a, 0x4d, 0x65,
+	0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x47, 0x65, 0x74, 0x12, 0x0e, 0x2e, 0x4d, 0x65, 0x6d, 0x70, 0x6f,
+	0x6f, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x4d, 0x65, 0x6d, 0x70, 0x6f,
+	0x6f, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0c, 0x4d, 0x65, 0x6d, 0x70,
+	0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x10, 0x2e, 0x4d, 0x65, 0x6d, 0x70, 0x6f,
+	0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x4d, 0x65, 0x6d,
+	0x70, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x42, 0x07, 0x5a, 0x05,
+	0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mempool_proto_rawDescOnce sync.Once
+	file_mempool_proto_rawDescData = file_mempool_proto_rawDesc
+)
+
+func file_mempool_proto_rawDescGZIP() []byte {
+	file_mempool_proto_rawDescOnce.Do(func() {
+		file_mempool_proto_rawDescData = protoimpl.X.CompressGZIP(file_mempool_proto_rawDescData)
+	})
+	return file_mempool_proto_rawDescData
+}
+
+var file_mempool_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_mempool_proto_goTypes = []any{
+	(*MempoolListReq)(nil),  // 0: MempoolListReq
+	(*MempoolListRes)(nil),  // 1: MempoolListRes
+	(*MempoolGetReq)(nil),   // 2: MempoolGetReq
+	(*MempoolGetRes)(nil),   // 3: MempoolGetRes
+	(*MempoolStatsReq)(nil), // 4: MempoolStatsReq
+	(*MempoolStatsRes)(nil), // 5: MempoolStatsRes
+}
+var file_mempool_proto_depIdxs = []int32{
+	0, // 0: Mempool.MempoolList:input_type -> MempoolListReq
+	2, // 1: Mempool.MempoolGet:input_type -> MempoolGetReq
+	4, // 2: Mempool.MempoolStats:input_type -> MempoolStatsReq
+	1, // 3: Mempool.MempoolList:output_type -> MempoolListRes
+	3, // 4: Mempool.MempoolGet:output_type -> MempoolGetRes
+	5, // 5: Mempool.MempoolStats:output_type -> MempoolStatsRes
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_mempool_proto_init() }
+func file_mempool_proto_init() {
+	if File_mempool_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_mempool_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*MempoolListReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mempool_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*MempoolListRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mempool_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*MempoolGetReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mempool_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*MempoolGetRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mempool_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*MempoolStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mempool_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*MempoolStatsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mempool_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mempool_proto_goTypes,
+		DependencyIndexes: file_mempool_proto_depIdxs,
+		MessageInfos:      file_mempool_proto_msgTypes,
+	}.Build()
+	File_mempool_proto = out.File
+	file_mempool_proto_rawDesc = nil
+	file_mempool_proto_goTypes = nil
+	file_mempool_proto_depIdxs = nil
+}
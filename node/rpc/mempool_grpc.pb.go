@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.2
+// source: mempool.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Mempool_MempoolList_FullMethodName  = "/Mempool/MempoolList"
+	Mempool_MempoolGet_FullMethodName   = "/Mempool/MempoolGet"
+	Mempool_MempoolStats_FullMethodName = "/Mempool/MempoolStats"
+)
+
+// MempoolClient is the client API for Mempool service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MempoolClient interface {
+	MempoolList(ctx context.Context, in *MempoolListReq, opts ...grpc.CallOption) (*MempoolListRes, error)
+	MempoolGet(ctx context.Context, in *MempoolGetReq, opts ...grpc.CallOption) (*MempoolGetRes, error)
+	MempoolStats(ctx context.Context, in *MempoolStatsReq, opts ...grpc.CallOption) (*MempoolStatsRes, error)
+}
+
+type mempoolClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMempoolClient(cc grpc.ClientConnInterface) MempoolClient {
+	return &mempoolClient{cc}
+}
+
+func (c *mempoolClient) MempoolList(ctx context.Context, in *MempoolListReq, opts ...grpc.CallOption) (*MempoolListRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MempoolListRes)
+	err := c.cc.Invoke(ctx, Mempool_MempoolList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolClient) MempoolGet(ctx context.Context, in *MempoolGetReq, opts ...grpc.CallOption) (*MempoolGetRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MempoolGetRes)
+	err := c.cc.Invoke(ctx, Mempool_MempoolGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolClient) MempoolStats(ctx context.Context, in *MempoolStatsReq, opts ...grpc.CallOption) (*MempoolStatsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MempoolStatsRes)
+	err := c.cc.Invoke(ctx, Mempool_MempoolStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MempoolServer is the server API for Mempool service.
+// All implementations must embed UnimplementedMempoolServer
+// for forward compatibility.
+type MempoolServer interface {
+	MempoolList(context.Context, *MempoolListReq) (*MempoolListRes, error)
+	MempoolGet(context.Context, *MempoolGetReq) (*MempoolGetRes, error)
+	MempoolStats(context.Context, *MempoolStatsReq) (*MempoolStatsRes, error)
+	mustEmbedUnimplementedMempoolServer()
+}
+
+// UnimplementedMempoolServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMempoolServer struct{}
+
+func (UnimplementedMempoolServer) MempoolList(context.Context, *MempoolListReq) (*MempoolListRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MempoolList not implemented")
+}
+func (UnimplementedMempoolServer) MempoolGet(context.Context, *MempoolGetReq) (*MempoolGetRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MempoolGet not implemented")
+}
+func (UnimplementedMempoolServer) MempoolStats(context.Context, *MempoolStatsReq) (*MempoolStatsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MempoolStats not implemented")
+}
+func (UnimplementedMempoolServer) mustEmbedUnimplementedMempoolServer() {}
+func (UnimplementedMempoolServer) testEmbeddedByValue()                 {}
+
+// UnsafeMempoolServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MempoolServer will
+// result in compilation errors.
+type UnsafeMempoolServer interface {
+	mustEmbedUnimplementedMempoolServer()
+}
+
+func RegisterMempoolServer(s grpc.ServiceRegistrar, srv MempoolServer) {
+	// If the following call pancis, it indicates UnimplementedMempoolServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Mempool_ServiceDesc, srv)
+}
+
+func _Mempool_MempoolList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MempoolListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServer).MempoolList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempool_MempoolList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServer).MempoolList(ctx, req.(*MempoolListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempool_MempoolGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MempoolGetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServer).MempoolGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempool_MempoolGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServer).MempoolGet(ctx, req.(*MempoolGetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempool_MempoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MempoolStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServer).MempoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempool_MempoolStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServer).MempoolStats(ctx, req.(*MempoolStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Mempool_ServiceDesc is the grpc.ServiceDesc for Mempool service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Mempool_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Mempool",
+	HandlerType: (*MempoolServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MempoolList",
+			Handler:    _Mempool_MempoolList_Handler,
+		},
+		{
+			MethodName: "MempoolGet",
+			Handler:    _Mempool_MempoolGet_Handler,
+		},
+		{
+			MethodName: "MempoolStats",
+			Handler:    _Mempool_MempoolStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mempool.proto",
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MempoolReader interface {
+  PendingTxs() []chain.PendingTx
+}
+
+type MempoolSrv struct {
+  UnimplementedMempoolServer
+  mempool MempoolReader
+}
+
+func NewMempoolSrv(mempool MempoolReader) *MempoolSrv {
+  return &MempoolSrv{mempool: mempool}
+}
+
+func (s *MempoolSrv) MempoolList(
+  _ context.Context, req *MempoolListReq,
+) (*MempoolListRes, error) {
+  txs := make([]chain.PendingTx, 0)
+  for _, tx := range s.mempool.PendingTxs() {
+    if len(req.From) > 0 && string(tx.From) != req.From ||
+      len(req.To) > 0 && string(tx.To) != req.To {
+      continue
+    }
+    txs = append(txs, tx)
+  }
+  jtxs, err := json.Marshal(txs)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &MempoolListRes{Txs: jtxs}
+  return res, nil
+}
+
+func (s *MempoolSrv) MempoolGet(
+  _ context.Context, req *MempoolGetReq,
+) (*MempoolGetRes, error) {
+  if len(req.Hash) == 0 {
+    return nil, status.Errorf(codes.InvalidArgument, "empty tx hash")
+  }
+  for _, tx := range s.mempool.PendingTxs() {
+    if strings.HasPrefix(tx.Hash().String(), req.Hash) {
+      jtx, err := json.Marshal(tx)
+      if err != nil {
+        return nil, status.Errorf(codes.Internal, err.Error())
+      }
+      res := &MempoolGetRes{Tx: jtx}
+      return res, nil
+    }
+  }
+  return nil, status.Errorf(codes.NotFound, "pending tx %v not found", req.Hash)
+}
+
+func (s *MempoolSrv) MempoolStats(
+  _ context.Context, req *MempoolStatsReq,
+) (*MempoolStatsRes, error) {
+  stats := chain.NewPendingStats(s.mempool.PendingTxs())
+  res := &MempoolStatsRes{}
+  for _, acc := range stats {
+    res.Count += acc.Count
+    res.Bytes += acc.Bytes
+  }
+  jstats, err := json.Marshal(stats)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res.Accounts = jstats
+  return res, nil
+}
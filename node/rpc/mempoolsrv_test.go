@@ -0,0 +1,151 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type pendingTxs []chain.PendingTx
+
+func (p pendingTxs) PendingTxs() []chain.PendingTx {
+  return p
+}
+
+func TestMempoolListGetStats(t *testing.T) {
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create two ready txs and one future tx from two accounts
+  acc1, err := chain.NewAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  acc2, err := chain.NewAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  var txs pendingTxs
+  for _, ptx := range []struct {
+    acc chain.Account
+    to chain.Address
+    fee, nonce uint64
+    ready bool
+  }{
+    {acc1, acc2.Address(), 2, 1, true},
+    {acc1, acc2.Address(), 5, 2, true},
+    {acc2, acc1.Address(), 3, 4, false},
+  } {
+    tx := chain.NewTx(chainName, ptx.acc.Address(), ptx.to, 12, ptx.nonce)
+    tx.Fee = ptx.fee
+    stx, err := ptx.acc.SignTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    txs = append(txs, chain.PendingTx{SigTx: stx, Ready: ptx.ready})
+  }
+  // Set up the gRPC server and client
+  conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+    mem := rpc.NewMempoolSrv(txs)
+    rpc.RegisterMempoolServer(grpcSrv, mem)
+  })
+  // Create the gRPC mempool client
+  cln := rpc.NewMempoolClient(conn)
+  t.Run("list by sender and recipient", func(t *testing.T) {
+    cases := []struct{ name, from, to string; exp int }{
+      {"all", "", "", 3},
+      {"from", string(acc1.Address()), "", 2},
+      {"to", "", string(acc2.Address()), 2},
+      {"from and to", string(acc2.Address()), string(acc2.Address()), 0},
+    }
+    for _, c := range cases {
+      // Call the MempoolList method with the filter
+      req := &rpc.MempoolListReq{From: c.from, To: c.to}
+      res, err := cln.MempoolList(ctx, req)
+      if err != nil {
+        t.Fatal(err)
+      }
+      var got []chain.PendingTx
+      err = json.Unmarshal(res.Txs, &got)
+      if err != nil {
+        t.Fatal(err)
+      }
+      // Verify that only the matching pending txs are listed
+      if len(got) != c.exp {
+        t.Errorf(
+          "invalid %v txs: expected %v, got %v", c.name, c.exp, len(got),
+        )
+      }
+    }
+  })
+  t.Run("get by hash prefix", func(t *testing.T) {
+    // Call the MempoolGet method with the hash prefix of the future tx
+    hash := txs[2].Hash()
+    req := &rpc.MempoolGetReq{Hash: hash.String()[:7]}
+    res, err := cln.MempoolGet(ctx, req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    var tx chain.PendingTx
+    err = json.Unmarshal(res.Tx, &tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the future tx is found
+    if tx.Hash() != hash || tx.Ready {
+      t.Errorf("invalid pending tx: expected %v, got %v", txs[2], tx)
+    }
+    // Verify that the unknown tx is not found
+    req = &rpc.MempoolGetReq{Hash: "unknown"}
+    _, err = cln.MempoolGet(ctx, req)
+    if err == nil {
+      t.Fatalf("expected not found error, got none")
+    }
+    got, exp := status.Code(err), codes.NotFound
+    if got != exp {
+      t.Errorf("wrong error: expected %v, got %v", exp, got)
+    }
+  })
+  t.Run("stats per account", func(t *testing.T) {
+    // Call the MempoolStats method
+    req := &rpc.MempoolStatsReq{}
+    res, err := cln.MempoolStats(ctx, req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    var stats []chain.PendingStats
+    err = json.Unmarshal(res.Accounts, &stats)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify the total count and bytes of the pending txs
+    var bytes uint64
+    for _, tx := range txs {
+      bytes += chain.TxSize(tx.SigTx)
+    }
+    if res.Count != 3 || res.Bytes != bytes {
+      t.Errorf(
+        "invalid totals: expected 3 txs %v bytes, got %v txs %v bytes",
+        bytes, res.Count, res.Bytes,
+      )
+    }
+    // Verify the fee and nonce range of the account with two txs
+    if len(stats) != 2 {
+      t.Fatalf("invalid accounts: expected 2, got %v", len(stats))
+    }
+    for _, acc := range stats {
+      if acc.Account != acc1.Address() {
+        continue
+      }
+      if acc.Count != 2 || acc.MinFee != 2 || acc.MaxFee != 5 ||
+        acc.MinNonce != 1 || acc.MaxNonce != 2 {
+        t.Errorf("invalid account stats: %+v", acc)
+      }
+    }
+  })
+}
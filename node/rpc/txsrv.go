@@ -7,6 +7,7 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
 	"google.golang.org/grpc"
@@ -15,6 +16,7 @@ import (
 )
 
 type TxApplier interface {
+  Chain() string
   Nonce(acc chain.Address) uint64
   ApplyTx(tx chain.SigTx) error
 }
@@ -26,31 +28,54 @@ type TxRelayer interface {
 type TxSrv struct {
   UnimplementedTxServer
   keyStoreDir string
-  blockStoreDir string
+  blockStore chain.BlockStore
   txApplier TxApplier
   txRelayer TxRelayer
+  minFee uint64
 }
 
 func NewTxSrv(
-  keyStoreDir string, blockStoreDir string,
-  txApplier TxApplier, txRelayer TxRelayer,
+  keyStoreDir string, blockStore chain.BlockStore,
+  txApplier TxApplier, txRelayer TxRelayer, minFee uint64,
 ) *TxSrv {
   return &TxSrv{
-    keyStoreDir: keyStoreDir, blockStoreDir: blockStoreDir,
-    txApplier: txApplier, txRelayer: txRelayer,
+    keyStoreDir: keyStoreDir, blockStore: blockStore,
+    txApplier: txApplier, txRelayer: txRelayer, minFee: minFee,
   }
 }
 
+// verifyFee rejects the tx with the fee below the minimum fee of the node
+func (s *TxSrv) verifyFee(tx chain.SigTx) error {
+  if tx.Fee < s.minFee {
+    return fmt.Errorf(
+      "tx error: fee below minimum fee %v\n%v\n", s.minFee, tx,
+    )
+  }
+  return nil
+}
+
 func (s *TxSrv) TxSign(_ context.Context, req *TxSignReq) (*TxSignRes, error) {
   path := filepath.Join(s.keyStoreDir, req.From)
   acc, err := chain.ReadAccount(path, []byte(req.Password))
   if err != nil {
     return nil, status.Errorf(codes.InvalidArgument, err.Error())
   }
+  // The explicit nonce signs the replacement of the pending tx
+  nonce := req.Nonce
+  if nonce == 0 {
+    nonce = s.txApplier.Nonce(chain.Address(req.From)) + 1
+  }
   tx := chain.NewTx(
-    chain.Address(req.From), chain.Address(req.To), req.Value,
-    s.txApplier.Nonce(chain.Address(req.From)) + 1,
+    s.txApplier.Chain(),
+    chain.Address(req.From), chain.Address(req.To), req.Value, nonce,
   )
+  tx.Fee = req.Fee
+  if req.ExpiryNumber > 0 || req.ExpiryTime > 0 {
+    tx.Expiry = &chain.TxExpiry{Number: req.ExpiryNumber}
+    if req.ExpiryTime > 0 {
+      tx.Expiry.Time = time.Unix(req.ExpiryTime, 0)
+    }
+  }
   stx, err := acc.SignTx(tx)
   if err != nil {
     return nil, status.Errorf(codes.Internal, err.Error())
@@ -69,6 +94,10 @@ func (s *TxSrv) TxSend(_ context.Context, req *TxSendReq) (*TxSendRes, error) {
   if err != nil {
     return nil, status.Errorf(codes.InvalidArgument, err.Error())
   }
+  err = s.verifyFee(tx)
+  if err != nil {
+    return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+  }
   err = s.txApplier.ApplyTx(tx)
   if err != nil {
     return nil, status.Errorf(codes.FailedPrecondition, err.Error())
@@ -99,6 +128,11 @@ func (s *TxSrv) TxReceive(
       continue
     }
     fmt.Printf("<== Tx receive\n%v\n", tx)
+    err = s.verifyFee(tx)
+    if err != nil {
+      fmt.Print(err)
+      continue
+    }
     err = s.txApplier.ApplyTx(tx)
     if err != nil {
       fmt.Print(err)
@@ -127,31 +161,60 @@ func sendTxSearchRes(
   return nil
 }
 
+func (s *TxSrv) findTx(hashPrefix string) (chain.SigBlock, int, bool, error) {
+  _, pos, found, err := s.blockStore.FindTx(hashPrefix)
+  if err != nil || !found {
+    return chain.SigBlock{}, 0, false, err
+  }
+  blk, err := s.blockStore.Block(pos.Number)
+  if err != nil {
+    return chain.SigBlock{}, 0, false, err
+  }
+  if int(pos.Index) >= len(blk.Txs) {
+    return chain.SigBlock{}, 0, false, fmt.Errorf(
+      "corrupted tx index: block %v has no tx %v", pos.Number, pos.Index,
+    )
+  }
+  return blk, int(pos.Index), true, nil
+}
+
 func (s *TxSrv) TxSearch(
   req *TxSearchReq, stream grpc.ServerStreamingServer[TxSearchRes],
 ) error {
-  blocks, closeBlocks, err := chain.ReadBlocks(s.blockStoreDir)
+  if len(req.Hash) > 0 {
+    blk, i, found, err := s.findTx(req.Hash)
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
+    }
+    if found {
+      err = sendTxSearchRes(blk, blk.Txs[i], stream)
+      if err != nil {
+        return status.Errorf(codes.Internal, err.Error())
+      }
+    }
+    return nil
+  }
+  blocks, closeBlocks, err := s.blockStore.BlocksBytes(1)
   if err != nil {
     return status.Errorf(codes.NotFound, err.Error())
   }
   defer closeBlocks()
   prefix := strings.HasPrefix
-  block: for err, blk := range blocks {
+  for err, jblk := range blocks {
+    if err != nil {
+      return status.Errorf(codes.Internal, err.Error())
+    }
+    var blk chain.SigBlock
+    err = json.Unmarshal(jblk, &blk)
     if err != nil {
       return status.Errorf(codes.Internal, err.Error())
     }
     for _, tx := range blk.Txs {
-      if len(req.Hash) > 0 && prefix(tx.Hash().String(), req.Hash) {
-        err = sendTxSearchRes(blk, tx, stream)
-        if err != nil {
-          return status.Errorf(codes.Internal, err.Error())
-        }
-        break block
-      }
       if len(req.From) > 0 && prefix(string(tx.From), req.From) ||
         len(req.To) > 0 && prefix(string(tx.To), req.To) ||
         len(req.Account) > 0 &&
-          (prefix(string(tx.From), req.From) || prefix(string(tx.To), req.To)) {
+          (prefix(string(tx.From), req.Account) ||
+            prefix(string(tx.To), req.Account)) {
         err := sendTxSearchRes(blk, tx, stream)
         if err != nil {
           return status.Errorf(codes.Internal, err.Error())
@@ -165,39 +228,35 @@ func (s *TxSrv) TxSearch(
 func (s *TxSrv) TxProve(
   _ context.Context, req *TxProveReq,
 ) (*TxProveRes, error) {
-  blocks, closeBlocks, err := chain.ReadBlocks(s.blockStoreDir)
+  txh, err := chain.DecodeHash(req.Hash)
   if err != nil {
-    return nil, status.Errorf(codes.NotFound, err.Error())
+    return nil, status.Errorf(codes.InvalidArgument, err.Error())
   }
-  defer closeBlocks()
-  for err, blk := range blocks {
-    if err != nil {
-      return nil, status.Errorf(codes.Internal, err.Error())
-    }
-    for _, tx := range blk.Txs {
-      if tx.Hash().String() == req.Hash {
-        merkleTree, err := chain.MerkleHash(
-          blk.Txs, chain.TxHash, chain.TxPairHash,
-        )
-        if err != nil {
-          return nil, status.Errorf(codes.Internal, err.Error())
-        }
-        merkleProof, err := chain.MerkleProve(tx.Hash(), merkleTree)
-        if err != nil {
-          return nil, status.Errorf(codes.Internal, err.Error())
-        }
-        jmp, err := json.Marshal(merkleProof)
-        if err != nil {
-          return nil, status.Errorf(codes.Internal, err.Error())
-        }
-        res := &TxProveRes{MerkleProof: jmp}
-        return res, nil
-      }
-    }
+  blk, i, found, err := s.findTx(txh.String())
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
   }
-  return nil, status.Errorf(
-    codes.NotFound, fmt.Sprintf("transaction %v not found", req.Hash),
+  if !found {
+    return nil, status.Errorf(
+      codes.NotFound, fmt.Sprintf("transaction %v not found", req.Hash),
+    )
+  }
+  merkleTree, err := chain.MerkleHash(
+    blk.Txs, chain.TxHash, chain.MerklePairHash(blk.Version),
   )
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  merkleProof, err := chain.MerkleProve(blk.Txs[i].Hash(), merkleTree)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  jmp, err := json.Marshal(merkleProof)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &TxProveRes{MerkleProof: jmp}
+  return res, nil
 }
 
 func (s *TxSrv) TxVerify(
@@ -216,7 +275,9 @@ func (s *TxSrv) TxVerify(
   if err != nil {
     return nil, status.Errorf(codes.InvalidArgument, err.Error())
   }
-  valid := chain.MerkleVerify(txh, merkleProof, merkleRoot, chain.TxPairHash)
+  // The Merkle proof of the tx in the legacy block pairs the legacy hashes
+  valid := chain.MerkleVerify(txh, merkleProof, merkleRoot, chain.PairHash) ||
+    chain.MerkleVerify(txh, merkleProof, merkleRoot, chain.TxPairHash)
   res := &TxVerifyRes{Valid: valid}
   return res, nil
 }
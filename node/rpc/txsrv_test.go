@@ -96,7 +96,10 @@ func TestTxSign(t *testing.T) {
   }
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, state, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir),
+      state, nil, 0,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
   })
   // Create the gRPC transaction client
@@ -148,24 +151,30 @@ func TestTxSend(t *testing.T) {
   }
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, state.Pending, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir),
+      state.Pending, nil, 2,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
   })
   // Create the gRPC transaction client
   cln := rpc.NewTxClient(conn)
-  // Define several valid and invalid transactions
-  cases := []struct{ name string; value uint64; err error }{
-    {"valid tx", 12, nil},
-    {"insufficient funds", 1000, fmt.Errorf("insufficient funds")},
+  // Define several valid and invalid transactions. The node accepts the
+  // transactions with the fee of at least 2
+  cases := []struct{ name string; value, fee uint64; err error }{
+    {"valid tx", 12, 2, nil},
+    {"fee below minimum", 12, 1, fmt.Errorf("fee below minimum")},
+    {"insufficient funds", 1000, 2, fmt.Errorf("insufficient funds")},
   }
   // Start sending transactions to the node
   for _, c := range cases {
     t.Run(c.name, func(t *testing.T) {
       // Create and sign a transaction
       tx := chain.NewTx(
-        acc.Address(), chain.Address("to"), c.value,
+        chainName, acc.Address(), chain.Address("to"), c.value,
         state.Pending.Nonce(acc.Address()) + 1,
       )
+      tx.Fee = c.fee
       stx, err := acc.SignTx(tx)
       if err != nil {
         t.Fatal(err)
@@ -202,7 +211,7 @@ func TestTxSend(t *testing.T) {
   // Verify that the balance of the initial owner account on the pending state
   // is correct
   got, exist := state.Pending.Balance(acc.Address())
-  exp := ownerBal - 12
+  exp := ownerBal - 12 - 2
   if !exist {
     t.Fatalf("balance does not exist")
   }
@@ -234,7 +243,10 @@ func TestTxReceive(t *testing.T) {
   }
   // Set up the gRPC server and gRPC client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, pending, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir),
+      pending, nil, 0,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
   })
   // Create the gRPC transaction client
@@ -250,7 +262,7 @@ func TestTxReceive(t *testing.T) {
   for _, value := range []uint64{12, 1000} {
     // Create and sign a transaction
     tx := chain.NewTx(
-      acc.Address(), chain.Address("to"), value,
+      chainName, acc.Address(), chain.Address("to"), value,
       pending.Nonce(acc.Address()) + 1,
     )
     stx, err := acc.SignTx(tx)
@@ -302,7 +314,10 @@ func TestTxSearch(t *testing.T) {
   }
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, state.Pending, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir),
+      state.Pending, nil, 0,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
   })
   var hash chain.Hash
@@ -328,6 +343,23 @@ func TestTxSearch(t *testing.T) {
       }
     }
   })
+  t.Run("search by involved account address", func(t *testing.T) {
+    // Search transactions by the involved account address that equals to the
+    // initial owner account address
+    ownerAcc, _ := genesisAccount(gen)
+    req := &rpc.TxSearchReq{Account: string(ownerAcc)}
+    txs := searchTxs(t, ctx, conn, req)
+    // Verify that both sent and received transactions are found
+    got, exp := len(txs), 4
+    if got != exp {
+      t.Errorf("not all transactions are found: expected %v, got %v", exp, got)
+    }
+    for _, tx := range txs {
+      if tx.From != ownerAcc && tx.To != ownerAcc {
+        t.Errorf("invalid transaction: account is not involved")
+      }
+    }
+  })
   t.Run("search by transaction hash", func(t *testing.T) {
     // Search transactions by the transaction hash of an existing transaction
     req := &rpc.TxSearchReq{Hash: hash.String()}
@@ -364,7 +396,10 @@ func TestTxProveVerify(t *testing.T) {
   }
   // Set up the gRPC server and client
   conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
-    tx := rpc.NewTxSrv(keyStoreDir, blockStoreDir, state.Pending, nil)
+    tx := rpc.NewTxSrv(
+      keyStoreDir, openBlockStore(t, blockStoreDir),
+      state.Pending, nil, 0,
+    )
     rpc.RegisterTxServer(grpcSrv, tx)
   })
   // Create the gRPC transaction client
@@ -0,0 +1,529 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: validator.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ValidatorListReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ValidatorListReq) Reset() {
+	*x = ValidatorListReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorListReq) ProtoMessage() {}
+
+func (x *ValidatorListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorListReq.ProtoReflect.Descriptor instead.
+func (*ValidatorListReq) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{0}
+}
+
+type ValidatorListRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validators []byte `protobuf:"bytes,1,opt,name=Validators,proto3" json:"Validators,omitempty"`
+}
+
+func (x *ValidatorListRes) Reset() {
+	*x = ValidatorListRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorListRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorListRes) ProtoMessage() {}
+
+func (x *ValidatorListRes) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorListRes.ProtoReflect.Descriptor instead.
+func (*ValidatorListRes) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidatorListRes) GetValidators() []byte {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type ValidatorSignReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      string `protobuf:"bytes,1,opt,name=Kind,proto3" json:"Kind,omitempty"`
+	From      string `protobuf:"bytes,2,opt,name=From,proto3" json:"From,omitempty"`
+	Validator string `protobuf:"bytes,3,opt,name=Validator,proto3" json:"Validator,omitempty"`
+	Power     uint64 `protobuf:"varint,4,opt,name=Power,proto3" json:"Power,omitempty"`
+	Password  string `protobuf:"bytes,5,opt,name=Password,proto3" json:"Password,omitempty"`
+	Fee       uint64 `protobuf:"varint,6,opt,name=Fee,proto3" json:"Fee,omitempty"`
+}
+
+func (x *ValidatorSignReq) Reset() {
+	*x = ValidatorSignReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSignReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSignReq) ProtoMessage() {}
+
+func (x *ValidatorSignReq) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSignReq.ProtoReflect.Descriptor instead.
+func (*ValidatorSignReq) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidatorSignReq) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ValidatorSignReq) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ValidatorSignReq) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *ValidatorSignReq) GetPower() uint64 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *ValidatorSignReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ValidatorSignReq) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+type ValidatorSignRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx []byte `protobuf:"bytes,1,opt,name=Tx,proto3" json:"Tx,omitempty"`
+}
+
+func (x *ValidatorSignRes) Reset() {
+	*x = ValidatorSignRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSignRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSignRes) ProtoMessage() {}
+
+func (x *ValidatorSignRes) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSignRes.ProtoReflect.Descriptor instead.
+func (*ValidatorSignRes) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidatorSignRes) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type ValidatorApproveReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx        []byte `protobuf:"bytes,1,opt,name=Tx,proto3" json:"Tx,omitempty"`
+	Validator string `protobuf:"bytes,2,opt,name=Validator,proto3" json:"Validator,omitempty"`
+	Password  string `protobuf:"bytes,3,opt,name=Password,proto3" json:"Password,omitempty"`
+}
+
+func (x *ValidatorApproveReq) Reset() {
+	*x = ValidatorApproveReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorApproveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorApproveReq) ProtoMessage() {}
+
+func (x *ValidatorApproveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorApproveReq.ProtoReflect.Descriptor instead.
+func (*ValidatorApproveReq) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidatorApproveReq) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *ValidatorApproveReq) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *ValidatorApproveReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ValidatorApproveRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx []byte `protobuf:"bytes,1,opt,name=Tx,proto3" json:"Tx,omitempty"`
+}
+
+func (x *ValidatorApproveRes) Reset() {
+	*x = ValidatorApproveRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorApproveRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorApproveRes) ProtoMessage() {}
+
+func (x *ValidatorApproveRes) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorApproveRes.ProtoReflect.Descriptor instead.
+func (*ValidatorApproveRes) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ValidatorApproveRes) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+var File_validator_proto protoreflect.FileDescriptor
+
+var file_validator_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x12, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x22, 0x32, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x10, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x12,
+	0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x46, 0x65, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x46, 0x65, 0x65, 0x22, 0x22, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02,
+	0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x54, 0x78, 0x22, 0x5f, 0x0a, 0x13,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x02, 0x54, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x25, 0x0a,
+	0x13, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x54, 0x78, 0x32, 0xb9, 0x01, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x11, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x0d, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x11, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73,
+	0x12, 0x3e, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x65, 0x12, 0x14, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_validator_proto_rawDescOnce sync.Once
+	file_validator_proto_rawDescData = file_validator_proto_rawDesc
+)
+
+func file_validator_proto_rawDescGZIP() []byte {
+	file_validator_proto_rawDescOnce.Do(func() {
+		file_validator_proto_rawDescData = protoimpl.X.CompressGZIP(file_validator_proto_rawDescData)
+	})
+	return file_validator_proto_rawDescData
+}
+
+var file_validator_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_validator_proto_goTypes = []any{
+	(*ValidatorListReq)(nil),    // 0: ValidatorListReq
+	(*ValidatorListRes)(nil),    // 1: ValidatorListRes
+	(*ValidatorSignReq)(nil),    // 2: ValidatorSignReq
+	(*ValidatorSignRes)(nil),    // 3: ValidatorSignRes
+	(*ValidatorApproveReq)(nil), // 4: ValidatorApproveReq
+	(*ValidatorApproveRes)(nil), // 5: ValidatorApproveRes
+}
+var file_validator_proto_depIdxs = []int32{
+	0, // 0: Validator.ValidatorList:input_type -> ValidatorListReq
+	2, // 1: Validator.ValidatorSign:input_type -> ValidatorSignReq
+	4, // 2: Validator.ValidatorApprove:input_type -> ValidatorApproveReq
+	1, // 3: Validator.ValidatorList:output_type -> ValidatorListRes
+	3, // 4: Validator.ValidatorSign:output_type -> ValidatorSignRes
+	5, // 5: Validator.ValidatorApprove:output_type -> ValidatorApproveRes
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_validator_proto_init() }
+func file_validator_proto_init() {
+	if File_validator_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_validator_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidatorListReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidatorListRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidatorSignReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidatorSignRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidatorApproveReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidatorApproveRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_validator_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_validator_proto_goTypes,
+		DependencyIndexes: file_validator_proto_depIdxs,
+		MessageInfos:      file_validator_proto_msgTypes,
+	}.Build()
+	File_validator_proto = out.File
+	file_validator_proto_rawDesc = nil
+	file_validator_proto_goTypes = nil
+	file_validator_proto_depIdxs = nil
+}
@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.2
+// source: validator.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Validator_ValidatorList_FullMethodName    = "/Validator/ValidatorList"
+	Validator_ValidatorSign_FullMethodName    = "/Validator/ValidatorSign"
+	Validator_ValidatorApprove_FullMethodName = "/Validator/ValidatorApprove"
+)
+
+// ValidatorClient is the client API for Validator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ValidatorClient interface {
+	ValidatorList(ctx context.Context, in *ValidatorListReq, opts ...grpc.CallOption) (*ValidatorListRes, error)
+	ValidatorSign(ctx context.Context, in *ValidatorSignReq, opts ...grpc.CallOption) (*ValidatorSignRes, error)
+	ValidatorApprove(ctx context.Context, in *ValidatorApproveReq, opts ...grpc.CallOption) (*ValidatorApproveRes, error)
+}
+
+type validatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewValidatorClient(cc grpc.ClientConnInterface) ValidatorClient {
+	return &validatorClient{cc}
+}
+
+func (c *validatorClient) ValidatorList(ctx context.Context, in *ValidatorListReq, opts ...grpc.CallOption) (*ValidatorListRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidatorListRes)
+	err := c.cc.Invoke(ctx, Validator_ValidatorList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *validatorClient) ValidatorSign(ctx context.Context, in *ValidatorSignReq, opts ...grpc.CallOption) (*ValidatorSignRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidatorSignRes)
+	err := c.cc.Invoke(ctx, Validator_ValidatorSign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *validatorClient) ValidatorApprove(ctx context.Context, in *ValidatorApproveReq, opts ...grpc.CallOption) (*ValidatorApproveRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidatorApproveRes)
+	err := c.cc.Invoke(ctx, Validator_ValidatorApprove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidatorServer is the server API for Validator service.
+// All implementations must embed UnimplementedValidatorServer
+// for forward compatibility.
+type ValidatorServer interface {
+	ValidatorList(context.Context, *ValidatorListReq) (*ValidatorListRes, error)
+	ValidatorSign(context.Context, *ValidatorSignReq) (*ValidatorSignRes, error)
+	ValidatorApprove(context.Context, *ValidatorApproveReq) (*ValidatorApproveRes, error)
+	mustEmbedUnimplementedValidatorServer()
+}
+
+// UnimplementedValidatorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedValidatorServer struct{}
+
+func (UnimplementedValidatorServer) ValidatorList(context.Context, *ValidatorListReq) (*ValidatorListRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorList not implemented")
+}
+func (UnimplementedValidatorServer) ValidatorSign(context.Context, *ValidatorSignReq) (*ValidatorSignRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorSign not implemented")
+}
+func (UnimplementedValidatorServer) ValidatorApprove(context.Context, *ValidatorApproveReq) (*ValidatorApproveRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorApprove not implemented")
+}
+func (UnimplementedValidatorServer) mustEmbedUnimplementedValidatorServer() {}
+func (UnimplementedValidatorServer) testEmbeddedByValue()                   {}
+
+// UnsafeValidatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ValidatorServer will
+// result in compilation errors.
+type UnsafeValidatorServer interface {
+	mustEmbedUnimplementedValidatorServer()
+}
+
+func RegisterValidatorServer(s grpc.ServiceRegistrar, srv ValidatorServer) {
+	// If the following call pancis, it indicates UnimplementedValidatorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Validator_ServiceDesc, srv)
+}
+
+func _Validator_ValidatorList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServer).ValidatorList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Validator_ValidatorList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServer).ValidatorList(ctx, req.(*ValidatorListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Validator_ValidatorSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorSignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServer).ValidatorSign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Validator_ValidatorSign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServer).ValidatorSign(ctx, req.(*ValidatorSignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Validator_ValidatorApprove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorApproveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServer).ValidatorApprove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Validator_ValidatorApprove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServer).ValidatorApprove(ctx, req.(*ValidatorApproveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Validator_ServiceDesc is the grpc.ServiceDesc for Validator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Validator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Validator",
+	HandlerType: (*ValidatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ValidatorList",
+			Handler:    _Validator_ValidatorList_Handler,
+		},
+		{
+			MethodName: "ValidatorSign",
+			Handler:    _Validator_ValidatorSign_Handler,
+		},
+		{
+			MethodName: "ValidatorApprove",
+			Handler:    _Validator_ValidatorApprove_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "validator.proto",
+}
@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ValidatorReader interface {
+  ValidatorSet() []chain.Validator
+}
+
+type ValidatorSrv struct {
+  UnimplementedValidatorServer
+  keyStoreDir string
+  valReader ValidatorReader
+  txApplier TxApplier
+}
+
+func NewValidatorSrv(
+  keyStoreDir string, valReader ValidatorReader, txApplier TxApplier,
+) *ValidatorSrv {
+  return &ValidatorSrv{
+    keyStoreDir: keyStoreDir, valReader: valReader, txApplier: txApplier,
+  }
+}
+
+func (s *ValidatorSrv) ValidatorList(
+  _ context.Context, req *ValidatorListReq,
+) (*ValidatorListRes, error) {
+  jvals, err := json.Marshal(s.valReader.ValidatorSet())
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &ValidatorListRes{Validators: jvals}
+  return res, nil
+}
+
+func (s *ValidatorSrv) ValidatorSign(
+  _ context.Context, req *ValidatorSignReq,
+) (*ValidatorSignRes, error) {
+  kind, err := chain.NewTxKind(req.Kind)
+  if err != nil || kind == chain.TxTransfer {
+    return nil, status.Errorf(
+      codes.InvalidArgument, "expected kind add|remove|power, got %v", req.Kind,
+    )
+  }
+  path := filepath.Join(s.keyStoreDir, req.From)
+  acc, err := chain.ReadAccount(path, []byte(req.Password))
+  if err != nil {
+    return nil, status.Errorf(codes.InvalidArgument, err.Error())
+  }
+  tx := chain.NewValidatorTx(
+    s.txApplier.Chain(), kind, chain.Address(req.From),
+    chain.Address(req.Validator), req.Power,
+    s.txApplier.Nonce(chain.Address(req.From)) + 1,
+  )
+  tx.Fee = req.Fee
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  jtx, err := json.Marshal(stx)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &ValidatorSignRes{Tx: jtx}
+  return res, nil
+}
+
+func (s *ValidatorSrv) ValidatorApprove(
+  _ context.Context, req *ValidatorApproveReq,
+) (*ValidatorApproveRes, error) {
+  var tx chain.SigTx
+  err := json.Unmarshal(req.Tx, &tx)
+  if err != nil {
+    return nil, status.Errorf(codes.InvalidArgument, err.Error())
+  }
+  if tx.Kind == chain.TxTransfer {
+    return nil, status.Errorf(
+      codes.InvalidArgument, "expected validator tx, got transfer",
+    )
+  }
+  path := filepath.Join(s.keyStoreDir, req.Validator)
+  acc, err := chain.ReadAccount(path, []byte(req.Password))
+  if err != nil {
+    return nil, status.Errorf(codes.InvalidArgument, err.Error())
+  }
+  stx, err := acc.ApproveTx(tx)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  jtx, err := json.Marshal(stx)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, err.Error())
+  }
+  res := &ValidatorApproveRes{Tx: jtx}
+  return res, nil
+}
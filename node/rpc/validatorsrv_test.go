@@ -0,0 +1,155 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/volodymyrprokopyuk/go-blockchain/chain"
+	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validatorList(
+  t *testing.T, ctx context.Context, cln rpc.ValidatorClient,
+) []chain.Validator {
+  req := &rpc.ValidatorListReq{}
+  res, err := cln.ValidatorList(ctx, req)
+  if err != nil {
+    t.Fatal(err)
+  }
+  var vals []chain.Validator
+  err = json.Unmarshal(res.Validators, &vals)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return vals
+}
+
+func TestValidatorSignApproveList(t *testing.T) {
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  // Create and persist the genesis with the single validator
+  gen, err := createGenesis()
+  if err != nil {
+    t.Fatal(err)
+  }
+  state := chain.NewState(gen)
+  // Re-create the authority account from the genesis
+  path := filepath.Join(keyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the account of the new validator
+  val, err := createAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Set up the gRPC server and client
+  conn := grpcClientConn(t, func(grpcSrv *grpc.Server) {
+    val := rpc.NewValidatorSrv(keyStoreDir, state, state.Pending)
+    rpc.RegisterValidatorServer(grpcSrv, val)
+  })
+  // Create the gRPC validator client
+  cln := rpc.NewValidatorClient(conn)
+  t.Run("add validator", func(t *testing.T) {
+    // Call the ValidatorSign method to sign the tx adding the new validator
+    req := &rpc.ValidatorSignReq{
+      Kind: "add", From: string(auth.Address()),
+      Validator: string(val.Address()), Power: 2, Password: authPass,
+    }
+    res, err := cln.ValidatorSign(ctx, req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    var tx chain.SigTx
+    err = json.Unmarshal(res.Tx, &tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the tx of the single validator is applied and confirmed
+    err = state.Pending.ApplyTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    clone := state.Clone()
+    blk, err := clone.CreateBlock(auth, 0)
+    if err != nil {
+      t.Fatal(err)
+    }
+    clone = state.Clone()
+    err = clone.ApplyBlock(blk)
+    if err != nil {
+      t.Fatal(err)
+    }
+    state.Apply(clone)
+    // Verify that the active validator set includes the new validator
+    exp := []chain.Validator{
+      {Address: auth.Address(), Power: 1}, {Address: val.Address(), Power: 2},
+    }
+    got := validatorList(t, ctx, cln)
+    if !slices.Equal(got, exp) {
+      t.Errorf("invalid validator set: expected %v, got %v", exp, got)
+    }
+  })
+  t.Run("change power with approval", func(t *testing.T) {
+    // Call the ValidatorSign method to sign the tx changing the voting power
+    req := &rpc.ValidatorSignReq{
+      Kind: "power", From: string(auth.Address()),
+      Validator: string(auth.Address()), Power: 3, Password: authPass,
+    }
+    res, err := cln.ValidatorSign(ctx, req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the tx without the approval of the validators with more
+    // than 2/3 of the voting power is rejected
+    var tx chain.SigTx
+    err = json.Unmarshal(res.Tx, &tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.Pending.ApplyTx(tx)
+    if err == nil {
+      t.Errorf("validator tx without approvals applied")
+    }
+    // Call the ValidatorApprove method to approve the tx by the new validator
+    appReq := &rpc.ValidatorApproveReq{
+      Tx: res.Tx, Validator: string(val.Address()), Password: ownerPass,
+    }
+    appRes, err := cln.ValidatorApprove(ctx, appReq)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the approved tx is applied
+    err = json.Unmarshal(appRes.Tx, &tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    err = state.Pending.ApplyTx(tx)
+    if err != nil {
+      t.Fatal(err)
+    }
+  })
+  t.Run("invalid tx kind", func(t *testing.T) {
+    // Call the ValidatorSign method with the unsupported tx kind
+    req := &rpc.ValidatorSignReq{
+      Kind: "transfer", From: string(auth.Address()),
+      Validator: string(val.Address()), Password: authPass,
+    }
+    _, err := cln.ValidatorSign(ctx, req)
+    // Verify that the unsupported tx kind is rejected
+    got, exp := status.Code(err), codes.InvalidArgument
+    if got != exp {
+      t.Errorf("invalid status code: expected %v, got %v", exp, got)
+    }
+  })
+}
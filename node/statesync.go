@@ -1,10 +1,13 @@
 package node
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"sync"
 
 	"github.com/volodymyrprokopyuk/go-blockchain/chain"
 	"github.com/volodymyrprokopyuk/go-blockchain/node/rpc"
@@ -16,13 +19,30 @@ type StateSync struct {
   cfg NodeCfg
   ctx context.Context
   state *chain.State
+  blockStore chain.BlockStore
+  archive *chain.Archive
   peerReader PeerReader
+  gen chain.SigGenesis
+  forks *chain.ForkTree
+  evRelayer EvidenceRelayer
+  mempool *Mempool
+  mtx sync.Mutex
 }
 
 func NewStateSync(
   ctx context.Context, cfg NodeCfg, peerReader PeerReader,
 ) *StateSync {
-  return &StateSync{ctx: ctx, cfg: cfg, peerReader: peerReader}
+  return &StateSync{
+    ctx: ctx, cfg: cfg, peerReader: peerReader, forks: chain.NewForkTree(),
+  }
+}
+
+func (s *StateSync) SetEvidenceRelayer(evRelayer EvidenceRelayer) {
+  s.evRelayer = evRelayer
+}
+
+func (s *StateSync) SetMempool(mempool *Mempool) {
+  s.mempool = mempool
 }
 
 func (s *StateSync) createGenesis() (chain.SigGenesis, error) {
@@ -54,9 +74,24 @@ func (s *StateSync) createGenesis() (chain.SigGenesis, error) {
   if err != nil {
     return chain.SigGenesis{}, err
   }
+  validators := make([]chain.Address, len(s.cfg.Validators))
+  for i, val := range s.cfg.Validators {
+    validators[i] = chain.Address(val)
+  }
   gen := chain.NewGenesis(
-    s.cfg.Chain, auth.Address(), acc.Address(), s.cfg.Balance,
+    s.cfg.Chain, auth.Address(), acc.Address(), s.cfg.Balance, validators...,
   )
+  gen.Consensus = s.cfg.Consensus
+  if gen.Consensus == chain.ConsensusPoW {
+    gen.PoW = &chain.PoW{
+      Difficulty: s.cfg.Difficulty, BlockTime: s.cfg.BlockTime,
+    }
+  }
+  if s.cfg.MaxTxs > 0 || s.cfg.MaxBytes > 0 {
+    gen.Limits = &chain.BlockLimits{
+      MaxTxs: s.cfg.MaxTxs, MaxBytes: s.cfg.MaxBytes,
+    }
+  }
   sgen, err := auth.SignGen(gen)
   if err != nil {
     return chain.SigGenesis{}, err
@@ -109,13 +144,369 @@ func (s *StateSync) syncGenesis() (chain.SigGenesis, error) {
   return gen, nil
 }
 
+// loadSnapshot restores the state from the latest valid snapshot that is
+// consistent with the block store
+func (s *StateSync) loadSnapshot(gen chain.SigGenesis) error {
+  snaps, err := chain.ReadSnapshots(s.cfg.BlockStoreDir)
+  if err != nil {
+    return err
+  }
+  height, err := s.blockStore.Height()
+  if err != nil {
+    return err
+  }
+  for err, snap := range snaps {
+    if err != nil {
+      fmt.Println(err)
+      continue
+    }
+    number := snap.LastBlock.Number
+    if number == 0 || number > height {
+      continue
+    }
+    blk, err := s.blockStore.Block(number)
+    if err != nil {
+      return err
+    }
+    if blk.Hash() != snap.LastBlock.Hash() {
+      fmt.Printf("snapshot error: block %v mismatch\n", number)
+      continue
+    }
+    state, err := chain.NewStateFromSnapshot(gen, snap)
+    if err != nil {
+      fmt.Println(err)
+      continue
+    }
+    s.state = state
+    fmt.Printf("=== Snapshot load\n%v\n", snap)
+    return nil
+  }
+  return nil
+}
+
+// snapshotState persists the state snapshot every snapshot interval blocks
+func (s *StateSync) snapshotState(number uint64) {
+  if s.cfg.SnapshotInterval == 0 || number % s.cfg.SnapshotInterval != 0 {
+    return
+  }
+  snap := s.state.Snapshot()
+  err := snap.Write(s.cfg.BlockStoreDir)
+  if err != nil {
+    fmt.Println(err)
+    return
+  }
+  fmt.Printf("=== Snapshot write\n%v\n", snap)
+}
+
+// verifyCommit verifies that the block is committed by more than 2/3 of the
+// validators in the BFT consensus
+func (s *StateSync) verifyCommit(blk chain.SigBlock) error {
+  if s.state.Consensus() != chain.ConsensusBFT {
+    return nil
+  }
+  return chain.VerifyCommit(blk, s.state.ValidatorSet())
+}
+
+// ApplyBlockToState applies the received block to the state and snapshots the
+// state every snapshot interval blocks
+func (s *StateSync) ApplyBlockToState(blk chain.SigBlock) error {
+  // The fork choice keeps the chain with the most work
+  lastBlock := s.state.LastBlock()
+  if chain.ChainWork(blk) <= chain.ChainWork(lastBlock) {
+    return fmt.Errorf("blk error: fork choice: not more work\n%v", blk)
+  }
+  err := s.verifyCommit(blk)
+  if err != nil {
+    return err
+  }
+  err = s.state.ApplyBlockToState(blk)
+  if err != nil {
+    return err
+  }
+  if s.mempool != nil {
+    s.mempool.Revalidate(nil)
+  }
+  err = s.archiveBlock(blk)
+  if err != nil {
+    return err
+  }
+  s.snapshotState(blk.Number)
+  return nil
+}
+
+// ApplyForkBlock applies the block extending the main chain to the state and the
+// block store. The block of a competing branch is kept in the fork tree, and
+// the chain is reorganized when the branch has more work than the main chain.
+// The reverted blocks of the old main chain and the applied blocks of the new
+// main chain are returned
+func (s *StateSync) ApplyForkBlock(blk chain.SigBlock) (
+  []chain.SigBlock, []chain.SigBlock, error,
+) {
+  s.mtx.Lock()
+  defer s.mtx.Unlock()
+  lastBlock := s.state.LastBlock()
+  err := s.detectEquivocation(blk, lastBlock.Number)
+  if err != nil {
+    return nil, nil, err
+  }
+  parent := lastBlock.Hash()
+  if lastBlock.Number == 0 {
+    parent = s.state.GenesisHash()
+  }
+  if blk.Parent == parent {
+    err := s.ApplyBlockToState(blk)
+    if err != nil {
+      return nil, nil, err
+    }
+    err = s.blockStore.WriteBlock(blk)
+    if err != nil {
+      return nil, nil, err
+    }
+    if blk.Number > chain.MaxReorgDepth {
+      s.forks.Prune(blk.Number - chain.MaxReorgDepth)
+    }
+    return nil, []chain.SigBlock{blk}, nil
+  }
+  // The committed blocks of the BFT consensus are final
+  if s.state.Consensus() == chain.ConsensusBFT {
+    return nil, nil, fmt.Errorf("blk error: fork of final chain\n%v", blk)
+  }
+  // The fork choice outside the proof of work counts every block as a unit of
+  // work
+  if s.state.Consensus() != chain.ConsensusPoW {
+    err := chain.VerifyNoWork(blk)
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  hash := blk.Hash()
+  _, onChain, err := s.blockStore.FindBlock(hash.String())
+  if err != nil {
+    return nil, nil, err
+  }
+  if onChain || s.forks.Contains(hash) {
+    return nil, nil, fmt.Errorf("blk error: known block\n%v", blk)
+  }
+  if blk.Number + chain.MaxReorgDepth <= lastBlock.Number ||
+    blk.Number > lastBlock.Number + chain.MaxReorgDepth {
+    return nil, nil, fmt.Errorf("blk error: fork too deep\n%v", blk)
+  }
+  s.forks.Add(blk)
+  fmt.Printf("=== Fork candidate\n%v", blk)
+  branch, err := s.forks.BestBranch(hash)
+  if err != nil {
+    return nil, nil, err
+  }
+  // The fork choice keeps the chain with the most work. The first seen chain
+  // is kept on equal work
+  tip := branch[len(branch) - 1]
+  if chain.ChainWork(tip) <= chain.ChainWork(lastBlock) {
+    return nil, nil, nil
+  }
+  connected, err := s.onMainChain(branch[0].Number - 1, branch[0].Parent)
+  if err != nil || !connected {
+    return nil, nil, err
+  }
+  reverted, err := s.reorg(branch)
+  if err != nil {
+    s.forks.Remove(branch)
+    return nil, nil, err
+  }
+  return reverted, branch, nil
+}
+
+// detectEquivocation reports the equivocation of the validator that signed the
+// block and a different block at the same block number either on the main
+// chain or in the fork tree
+func (s *StateSync) detectEquivocation(blk chain.SigBlock, last uint64) error {
+  if s.state.Consensus() == chain.ConsensusPoW {
+    return nil
+  }
+  blks := s.forks.Blocks(blk.Number)
+  if blk.Number > 0 && blk.Number <= last {
+    mainBlk, err := s.blockStore.Block(blk.Number)
+    if err != nil {
+      return err
+    }
+    blks = append(blks, mainBlk)
+  }
+  for _, b := range blks {
+    if b.Hash() != blk.Hash() {
+      reportEquivocation(s.state, s.evRelayer, b, blk)
+    }
+  }
+  return nil
+}
+
+// onMainChain checks whether the block hash is on the main chain at the block
+// number
+func (s *StateSync) onMainChain(number uint64, hash chain.Hash) (bool, error) {
+  if number == 0 {
+    return hash == s.state.GenesisHash(), nil
+  }
+  if number > s.state.LastBlock().Number {
+    return false, nil
+  }
+  blk, err := s.blockStore.Block(number)
+  if err != nil {
+    return false, err
+  }
+  return blk.Hash() == hash, nil
+}
+
+// stateAt reconstructs the state at the block number from the latest snapshot
+// at or before the block number and the blocks from the block store
+func (s *StateSync) stateAt(number uint64) (*chain.State, error) {
+  state := chain.NewState(s.gen)
+  snaps, err := chain.ReadSnapshots(s.cfg.BlockStoreDir)
+  if err != nil {
+    return nil, err
+  }
+  for err, snap := range snaps {
+    if err != nil {
+      continue
+    }
+    snapNumber := snap.LastBlock.Number
+    if snapNumber == 0 || snapNumber > number {
+      continue
+    }
+    blk, err := s.blockStore.Block(snapNumber)
+    if err != nil || blk.Hash() != snap.LastBlock.Hash() {
+      continue
+    }
+    snapState, err := chain.NewStateFromSnapshot(s.gen, snap)
+    if err != nil {
+      continue
+    }
+    state = snapState
+    break
+  }
+  for n := state.LastBlock().Number + 1; n <= number; n++ {
+    blk, err := s.blockStore.Block(n)
+    if err != nil {
+      return nil, err
+    }
+    err = state.ApplyBlock(blk)
+    if err != nil {
+      return nil, err
+    }
+  }
+  return state, nil
+}
+
+// reorg rolls the state, the block store, and the archive back to the fork
+// point and forward along the competing branch. The main chain is not changed
+// when any block of the branch is invalid
+func (s *StateSync) reorg(branch []chain.SigBlock) ([]chain.SigBlock, error) {
+  forkNumber := branch[0].Number - 1
+  lastNumber := s.state.LastBlock().Number
+  if lastNumber - forkNumber > chain.MaxReorgDepth {
+    return nil, fmt.Errorf("blk error: reorg too deep\n%v", branch[0])
+  }
+  state, err := s.stateAt(forkNumber)
+  if err != nil {
+    return nil, err
+  }
+  states := make([]*chain.State, 0, len(branch))
+  for _, blk := range branch {
+    err := state.ApplyBlock(blk)
+    if err != nil {
+      return nil, err
+    }
+    if s.archive != nil {
+      states = append(states, state.Clone())
+    }
+  }
+  reverted := make([]chain.SigBlock, 0, lastNumber - forkNumber)
+  for n := forkNumber + 1; n <= lastNumber; n++ {
+    blk, err := s.blockStore.Block(n)
+    if err != nil {
+      return nil, err
+    }
+    reverted = append(reverted, blk)
+  }
+  err = s.blockStore.Truncate(forkNumber)
+  if err != nil {
+    return nil, err
+  }
+  for _, blk := range branch {
+    err := s.blockStore.WriteBlock(blk)
+    if err != nil {
+      return nil, err
+    }
+  }
+  if s.archive != nil {
+    err := s.archive.Truncate(forkNumber)
+    if err != nil {
+      return nil, err
+    }
+    for i, blk := range branch {
+      err := s.archive.WriteBlock(blk, states[i])
+      if err != nil {
+        return nil, err
+      }
+    }
+  }
+  s.state.Reorg(state, reverted)
+  if s.mempool != nil {
+    s.mempool.Revalidate(reverted)
+  }
+  // The reverted blocks become the candidate blocks of the competing branch
+  s.forks.Remove(branch)
+  for _, blk := range reverted {
+    s.forks.Add(blk)
+  }
+  fmt.Printf(
+    "=== Chain reorg at %v: reverted %v, applied %v blocks\n",
+    forkNumber, len(reverted), len(branch),
+  )
+  s.snapshotState(s.state.LastBlock().Number)
+  return reverted, nil
+}
+
+// openArchive opens the archive of the historical account states and returns
+// the last archived block number
+func (s *StateSync) openArchive(gen chain.SigGenesis) (uint64, error) {
+  archive, err := chain.OpenArchive(s.cfg.BlockStoreDir)
+  if err != nil {
+    return 0, err
+  }
+  s.archive = archive
+  height, exist, err := archive.Height()
+  if err != nil {
+    return 0, err
+  }
+  if !exist {
+    err = archive.WriteGenesis(gen)
+    if err != nil {
+      return 0, err
+    }
+  }
+  return height, nil
+}
+
+// archiveBlock archives the account states changed by the block in the
+// archival mode
+func (s *StateSync) archiveBlock(blk chain.SigBlock) error {
+  if s.archive == nil {
+    return nil
+  }
+  return s.archive.WriteBlock(blk, s.state)
+}
+
 func (s *StateSync) readBlocks() error {
-  blocks, closeBlocks, err := chain.ReadBlocks(s.cfg.BlockStoreDir)
+  from := s.state.LastBlock().Number + 1
+  blocks, closeBlocks, err := s.blockStore.BlocksBytes(from)
   if err != nil {
     return err
   }
   defer closeBlocks()
-  for err, blk := range blocks {
+  for err, jblk := range blocks {
+    if err != nil {
+      return err
+    }
+    var blk chain.SigBlock
+    err = json.Unmarshal(jblk, &blk)
     if err != nil {
       return err
     }
@@ -125,11 +516,116 @@ func (s *StateSync) readBlocks() error {
       return err
     }
     s.state.Apply(clone)
+    err = s.archiveBlock(blk)
+    if err != nil {
+      return err
+    }
   }
   return nil
 }
 
-func (s *StateSync) grpcBlockSync(peer string) (
+func (s *StateSync) grpcSnapshotSync() ([]byte, error) {
+  conn, err := grpc.NewClient(
+    s.cfg.SeedAddr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+  cln := rpc.NewBlockClient(conn)
+  req := &rpc.SnapshotSyncReq{}
+  stream, err := cln.SnapshotSync(s.ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  var jsnap []byte
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      return jsnap, nil
+    }
+    if err != nil {
+      return nil, err
+    }
+    jsnap = append(jsnap, res.Chunk...)
+  }
+}
+
+// fastSync restores the state from the latest snapshot of the seed node. The
+// snapshot is verified against the state root of the validator-signed last
+// block of the snapshot. The proof of work block is not signed by a known
+// validator, and the work of the snapshot chain is only known after the full
+// sync, so the proof of work chain is always fully synced
+func (s *StateSync) fastSync(gen chain.SigGenesis) error {
+  if gen.ConsensusType() == chain.ConsensusPoW {
+    return fmt.Errorf("snapshot error: fast sync of proof of work chain")
+  }
+  jsnap, err := s.grpcSnapshotSync()
+  if err != nil {
+    return err
+  }
+  var snap chain.SealSnapshot
+  err = json.Unmarshal(jsnap, &snap)
+  if err != nil {
+    return err
+  }
+  // The validator set and the jailed validators of the snapshot are not
+  // committed by the state root of the last block. The fast sync falls back to
+  // the full sync when the snapshot changes the genesis validator set
+  genValidators := chain.NewValidators(gen.ValidatorSet())
+  if len(snap.Validators) > 0 &&
+    !slices.Equal(snap.Validators, genValidators) || len(snap.Jailed) > 0 {
+    return fmt.Errorf("snapshot error: validator set changed\n%v", snap)
+  }
+  valid, err := chain.VerifyBlock(snap.LastBlock, gen.ValidatorSet())
+  if err != nil {
+    return err
+  }
+  if !valid {
+    return fmt.Errorf("snapshot error: invalid block signature\n%v", snap)
+  }
+  err = s.verifyCommit(snap.LastBlock)
+  if err != nil {
+    return err
+  }
+  state, err := chain.NewStateFromSnapshot(gen, snap)
+  if err != nil {
+    return err
+  }
+  err = snap.Write(s.cfg.BlockStoreDir)
+  if err != nil {
+    return err
+  }
+  s.state = state
+  fmt.Printf("=== Fast sync\n%v\n", snap)
+  return nil
+}
+
+// backfillBlock verifies the block that is already reflected in the state
+// restored from the fast sync snapshot. Instead of re-applying the block, the
+// block is linked to the parent block, and the last backfilled block is linked
+// to the snapshot
+func (s *StateSync) backfillBlock(blk chain.SigBlock, parent chain.Hash) error {
+  if blk.Parent != parent {
+    return fmt.Errorf("blk error: invalid parent hash\n%v", blk)
+  }
+  merkleTree, err := chain.MerkleHash(
+    blk.Txs, chain.TxHash, chain.MerklePairHash(blk.Version),
+  )
+  if err != nil {
+    return err
+  }
+  if merkleTree[0] != blk.MerkleRoot {
+    return fmt.Errorf("blk error: invalid Merkle root\n%v", blk)
+  }
+  lastBlock := s.state.LastBlock()
+  if blk.Number == lastBlock.Number && blk.Hash() != lastBlock.Hash() {
+    return fmt.Errorf("blk error: block does not match snapshot\n%v", blk)
+  }
+  return nil
+}
+
+func (s *StateSync) grpcBlockSync(peer string, number uint64) (
   func(yield (func(err error, jblk []byte) bool)), func(), error,
 ) {
   conn, err := grpc.NewClient(
@@ -142,9 +638,10 @@ func (s *StateSync) grpcBlockSync(peer string) (
     conn.Close()
   }
   cln := rpc.NewBlockClient(conn)
-  req := &rpc.BlockSyncReq{Number: s.state.LastBlock().Number + 1}
+  req := &rpc.BlockSyncReq{Number: number}
   stream, err := cln.BlockSync(s.ctx, req)
   if err != nil {
+    close()
     return nil, nil, err
   }
   more := true
@@ -164,35 +661,127 @@ func (s *StateSync) grpcBlockSync(peer string) (
   return blocks, close, nil
 }
 
+func (s *StateSync) grpcBlockLatest(peer string) (chain.SigBlock, error) {
+  conn, err := grpc.NewClient(
+    peer, grpc.WithTransportCredentials(insecure.NewCredentials()),
+  )
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  defer conn.Close()
+  cln := rpc.NewBlockClient(conn)
+  req := &rpc.BlockLatestReq{}
+  res, err := cln.BlockLatest(s.ctx, req)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  var blk chain.SigBlock
+  err = json.Unmarshal(res.Block, &blk)
+  return blk, err
+}
+
+// bestPeers orders the peers by the work of the peer chain, so the blocks are
+// synced from the chain with the most work first
+func (s *StateSync) bestPeers() []string {
+  peers := s.peerReader.Peers()
+  works := make(map[string]uint64, len(peers))
+  for _, peer := range peers {
+    blk, err := s.grpcBlockLatest(peer)
+    if err != nil {
+      continue
+    }
+    if s.state.Consensus() != chain.ConsensusPoW &&
+      chain.VerifyNoWork(blk) != nil {
+      continue
+    }
+    works[peer] = chain.ChainWork(blk)
+  }
+  slices.SortStableFunc(peers, func(a, b string) int {
+    return cmp.Compare(works[b], works[a])
+  })
+  return peers
+}
+
 func (s *StateSync) syncBlocks() error {
-  for _, peer := range s.peerReader.Peers() {
-    blocks, closeBlocks, err := s.grpcBlockSync(peer)
+  height, err := s.blockStore.Height()
+  if err != nil {
+    return err
+  }
+  parent := s.state.GenesisHash()
+  if height > 0 {
+    blk, err := s.blockStore.Block(height)
     if err != nil {
       return err
     }
-    defer closeBlocks()
-    for err, jblk := range blocks {
-      if err != nil {
-        return err
-      }
-      var blk chain.SigBlock
-      err = json.Unmarshal(jblk, &blk)
+    parent = blk.Hash()
+  }
+  // The next peer continues the sync from the last synced block when the peer
+  // fails
+  for _, peer := range s.bestPeers() {
+    height, parent, err = s.syncPeerBlocks(peer, height, parent)
+    if err != nil {
+      fmt.Println(err)
+    }
+  }
+  return nil
+}
+
+// syncPeerBlocks syncs the blocks after the height from the peer and returns
+// the last synced block number and block hash
+func (s *StateSync) syncPeerBlocks(
+  peer string, height uint64, parent chain.Hash,
+) (uint64, chain.Hash, error) {
+  blocks, closeBlocks, err := s.grpcBlockSync(peer, height + 1)
+  if err != nil {
+    return height, parent, err
+  }
+  defer closeBlocks()
+  for err, jblk := range blocks {
+    if err != nil {
+      return height, parent, err
+    }
+    var blk chain.SigBlock
+    err = json.Unmarshal(jblk, &blk)
+    if err != nil {
+      return height, parent, err
+    }
+    err = s.verifyCommit(blk)
+    if err != nil {
+      return height, parent, err
+    }
+    if blk.Number <= s.state.LastBlock().Number {
+      err = s.backfillBlock(blk, parent)
       if err != nil {
-        return err
+        return height, parent, err
       }
+    } else {
       clone := s.state.Clone()
       err = clone.ApplyBlock(blk)
       if err != nil {
-        return err
+        return height, parent, err
       }
       s.state.Apply(clone)
-      err = blk.Write(s.cfg.BlockStoreDir)
+      err = s.archiveBlock(blk)
       if err != nil {
-        return err
+        return height, parent, err
       }
     }
+    err = s.blockStore.WriteBlock(blk)
+    if err != nil {
+      return height, parent, err
+    }
+    s.snapshotState(blk.Number)
+    height, parent = blk.Number, blk.Hash()
   }
-  return nil
+  return height, parent, nil
+}
+
+func (s *StateSync) BlockStore() chain.BlockStore {
+  return s.blockStore
+}
+
+func (s *StateSync) Archive() *chain.Archive {
+  return s.archive
 }
 
 func (s *StateSync) SyncState() (*chain.State, error) {
@@ -217,11 +806,50 @@ func (s *StateSync) SyncState() (*chain.State, error) {
   if !valid {
     return nil, fmt.Errorf("invalid genesis signature")
   }
+  s.gen = gen
   s.state = chain.NewState(gen)
-  err = chain.InitBlockStore(s.cfg.BlockStoreDir)
+  s.blockStore, err = chain.OpenBlockStore(
+    s.cfg.BlockStoreDir, s.cfg.StoreType,
+  )
   if err != nil {
     return nil, err
   }
+  rec := s.blockStore.Recovery()
+  if rec.DroppedBytes > 0 {
+    // The truncated blocks are re-synced from the peers
+    fmt.Printf("=== Block store recovery: %v\n", rec)
+  }
+  height, err := s.blockStore.Height()
+  if err != nil {
+    return nil, err
+  }
+  replay := s.cfg.Replay
+  if s.cfg.Archive {
+    archiveHeight, err := s.openArchive(gen)
+    if err != nil {
+      return nil, err
+    }
+    if archiveHeight < height {
+      // The archive is rebuilt by replaying the block store from the genesis
+      fmt.Printf("=== Archive: rebuilding from block %v\n", archiveHeight)
+      replay = true
+    }
+  }
+  if replay {
+    fmt.Printf("=== Full replay of the block store\n")
+  } else {
+    err = s.loadSnapshot(gen)
+    if err != nil {
+      return nil, err
+    }
+  }
+  fastSync := s.cfg.FastSync && !s.cfg.Archive && !s.cfg.Bootstrap
+  if fastSync && height == 0 {
+    err = s.fastSync(gen)
+    if err != nil {
+      fmt.Printf("=== Fast sync: fall back to full sync: %v\n", err)
+    }
+  }
   err = s.readBlocks()
   if err != nil {
     return nil, err
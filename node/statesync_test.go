@@ -36,6 +36,16 @@ func genesisAccount(gen chain.SigGenesis) (chain.Address, uint64) {
   return "", 0
 }
 
+func openBlockStore(
+  t *testing.T, blockStoreDir, storeType string,
+) chain.BlockStore {
+  blockStore, err := chain.OpenBlockStore(blockStoreDir, storeType)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return blockStore
+}
+
 func createStateSync(
   ctx context.Context, peerReader node.PeerReader, bootstrap bool,
 ) (*chain.State, error) {
@@ -51,6 +61,7 @@ func createStateSync(
     nodeCfg = node.NodeCfg{
       NodeAddr: nodeAddr, SeedAddr: bootAddr,
       KeyStoreDir: keyStoreDir, BlockStoreDir: blockStoreDir,
+      StoreType: chain.KVStoreType,
     }
   }
   stateSync := node.NewStateSync(ctx, nodeCfg, peerReader)
@@ -89,7 +100,7 @@ func createBlocks(
   for _, txs := range blocks {
     for _, t := range txs {
       tx := chain.NewTx(
-        t.from.Address(), t.to.Address(), t.value,
+        chainName, t.from.Address(), t.to.Address(), t.value,
         state.Pending.Nonce(t.from.Address()) + 1,
       )
       stx, err := t.from.SignTx(tx)
@@ -102,7 +113,7 @@ func createBlocks(
       }
     }
     clone := state.Clone()
-    blk, err := clone.CreateBlock(auth)
+    blk, err := clone.CreateBlock(auth, 0)
     if err != nil {
       return err
     }
@@ -180,7 +191,11 @@ func TestStateSync(t *testing.T) {
   }
   // Start the gRPC server on the bootstrap node
   grpcStartSvr(t, bootAddr, func(grpcSrv *grpc.Server) {
-    blk := rpc.NewBlockSrv(bootBlockStoreDir, nil, bootState, nil)
+    blk := rpc.NewBlockSrv(
+      bootBlockStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      nil, bootState, nil,
+    )
     rpc.RegisterBlockServer(grpcSrv, blk)
   })
   // Wait for the gRPC server of the bootstrap node to start
@@ -205,4 +220,380 @@ func TestStateSync(t *testing.T) {
   if gotLastBlock.Parent != expLastBlock.Parent {
     t.Errorf("invalid block parent")
   }
+  t.Run("restart from snapshot", func(t *testing.T) {
+    // Persist the state snapshot on the new node
+    snap := nodeState.Snapshot()
+    err := snap.Write(blockStoreDir)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Re-synchronize the state on the new node from the latest snapshot
+    nodeState, err := createStateSync(ctx, nodePeerDisc, false)
+    if err != nil {
+      t.Fatal(err)
+    }
+    // Verify that the state restored from the snapshot is equal to the state
+    // of the bootstrap node
+    if nodeState.LastBlock().Hash() != expLastBlock.Hash() {
+      t.Errorf("invalid last block")
+    }
+    expBalance, _ := bootState.Balance(ownerAcc)
+    gotBalance, _ := nodeState.Balance(ownerAcc)
+    if gotBalance != expBalance {
+      t.Errorf("invalid balance: expected %v, got %v", expBalance, gotBalance)
+    }
+  })
+}
+
+func TestFastSync(t *testing.T) {
+  defer os.RemoveAll(bootKeyStoreDir)
+  defer os.RemoveAll(bootBlockStoreDir)
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  wg := new(sync.WaitGroup)
+  // Create the peer discovery without starting for the bootstrap node
+  bootPeerDisc := createPeerDiscovery(ctx, wg, true, false)
+  // Initialize the state on the bootstrap node by creating the genesis
+  bootState, err := createStateSync(ctx, bootPeerDisc, true)
+  if err != nil {
+    t.Fatal(err)
+  }
+  gen, err := chain.ReadGenesis(bootBlockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create several confirmed blocks on the bootstrap node
+  err = createBlocks(bootKeyStoreDir, bootBlockStoreDir, gen, bootState)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Persist the state snapshot on the bootstrap node
+  snap := bootState.Snapshot()
+  err = snap.Write(bootBlockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create more confirmed blocks after the snapshot on the bootstrap node
+  err = createBlocks(bootKeyStoreDir, bootBlockStoreDir, gen, bootState)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Start the gRPC server on the bootstrap node
+  grpcStartSvr(t, bootAddr, func(grpcSrv *grpc.Server) {
+    blk := rpc.NewBlockSrv(
+      bootBlockStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      nil, bootState, nil,
+    )
+    rpc.RegisterBlockServer(grpcSrv, blk)
+  })
+  // Wait for the gRPC server of the bootstrap node to start
+  time.Sleep(100 * time.Millisecond)
+  // Create the peer discovery without starting for the new node
+  nodePeerDisc := createPeerDiscovery(ctx, wg, false, false)
+  // Synchronize the state on the new node by fetching the latest snapshot and
+  // the blocks after the snapshot from the bootstrap node
+  nodeCfg := node.NodeCfg{
+    NodeAddr: nodeAddr, SeedAddr: bootAddr, FastSync: true,
+    KeyStoreDir: keyStoreDir, BlockStoreDir: blockStoreDir,
+  }
+  stateSync := node.NewStateSync(ctx, nodeCfg, nodePeerDisc)
+  nodeState, err := stateSync.SyncState()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the state of the new node is equal to the state of the
+  // bootstrap node
+  gotLastBlock, expLastBlock := nodeState.LastBlock(), bootState.LastBlock()
+  if gotLastBlock.Hash() != expLastBlock.Hash() {
+    t.Errorf(
+      "invalid last block: expected %v, got %v",
+      expLastBlock.Number, gotLastBlock.Number,
+    )
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  expBalance, _ := bootState.Balance(ownerAcc)
+  gotBalance, _ := nodeState.Balance(ownerAcc)
+  if gotBalance != expBalance {
+    t.Errorf("invalid balance: expected %v, got %v", expBalance, gotBalance)
+  }
+  // Verify that the blocks before the snapshot are backfilled on the block
+  // store of the new node
+  height, err := stateSync.BlockStore().Height()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if height != expLastBlock.Number {
+    t.Errorf(
+      "invalid height: expected %v, got %v", expLastBlock.Number, height,
+    )
+  }
+}
+
+func TestFastSyncForgedSnapshot(t *testing.T) {
+  defer os.RemoveAll(bootKeyStoreDir)
+  defer os.RemoveAll(bootBlockStoreDir)
+  defer os.RemoveAll(keyStoreDir)
+  defer os.RemoveAll(blockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  wg := new(sync.WaitGroup)
+  // Initialize the state on the bootstrap node and create several confirmed
+  // blocks
+  bootPeerDisc := createPeerDiscovery(ctx, wg, true, false)
+  bootState, err := createStateSync(ctx, bootPeerDisc, true)
+  if err != nil {
+    t.Fatal(err)
+  }
+  gen, err := chain.ReadGenesis(bootBlockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  err = createBlocks(bootKeyStoreDir, bootBlockStoreDir, gen, bootState)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Forge the snapshot with an invented balance and the last block signed by
+  // the forged validator listed in the snapshot validator set
+  forger, err := chain.NewAccount()
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, _ := genesisAccount(gen)
+  balances := map[chain.Address]uint64{ownerAcc: 1_000_000}
+  nonces := map[chain.Address]uint64{}
+  stateRoot, err := chain.StateRoot(balances, nonces)
+  if err != nil {
+    t.Fatal(err)
+  }
+  tx := chain.NewTx(chainName, forger.Address(), chain.Address("to"), 1, 1)
+  stx, err := forger.SignTx(tx)
+  if err != nil {
+    t.Fatal(err)
+  }
+  lastBlock := bootState.LastBlock()
+  blk, err := chain.NewBlock(
+    lastBlock.Number + 1, lastBlock.Hash(), []chain.SigTx{stx}, stateRoot,
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  sblk, err := forger.SignBlock(blk)
+  if err != nil {
+    t.Fatal(err)
+  }
+  snap := chain.NewSealSnapshot(chain.Snapshot{
+    GenesisHash: bootState.GenesisHash(), Balances: balances, Nonces: nonces,
+    LastBlock: sblk,
+    Validators: []chain.Validator{{Address: forger.Address(), Power: 1}},
+  })
+  err = snap.Write(bootBlockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Start the gRPC server on the bootstrap node
+  grpcStartSvr(t, bootAddr, func(grpcSrv *grpc.Server) {
+    blk := rpc.NewBlockSrv(
+      bootBlockStoreDir,
+      openBlockStore(t, bootBlockStoreDir, chain.FileStoreType),
+      nil, bootState, nil,
+    )
+    rpc.RegisterBlockServer(grpcSrv, blk)
+  })
+  // Wait for the gRPC server of the bootstrap node to start
+  time.Sleep(100 * time.Millisecond)
+  // Synchronize the state on the new node with the fast sync
+  nodePeerDisc := createPeerDiscovery(ctx, wg, false, false)
+  nodeCfg := node.NodeCfg{
+    NodeAddr: nodeAddr, SeedAddr: bootAddr, FastSync: true,
+    KeyStoreDir: keyStoreDir, BlockStoreDir: blockStoreDir,
+  }
+  stateSync := node.NewStateSync(ctx, nodeCfg, nodePeerDisc)
+  nodeState, err := stateSync.SyncState()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the forged snapshot is rejected and the new node falls back to
+  // the full sync of the confirmed blocks
+  gotLastBlock := nodeState.LastBlock()
+  if gotLastBlock.Hash() != lastBlock.Hash() {
+    t.Errorf(
+      "invalid last block: expected %v, got %v",
+      lastBlock.Number, gotLastBlock.Number,
+    )
+  }
+  expBalance, _ := bootState.Balance(ownerAcc)
+  gotBalance, _ := nodeState.Balance(ownerAcc)
+  if gotBalance != expBalance {
+    t.Errorf("invalid balance: expected %v, got %v", expBalance, gotBalance)
+  }
+}
+
+func createForkBlock(
+  auth, acc chain.Account, state *chain.State, value uint64,
+) (chain.SigBlock, error) {
+  tx := chain.NewTx(
+    chainName, acc.Address(), chain.Address("to"), value,
+    state.Pending.Nonce(acc.Address()) + 1,
+  )
+  stx, err := acc.SignTx(tx)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  err = state.Pending.ApplyTx(stx)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  clone := state.Clone()
+  blk, err := clone.CreateBlock(auth, 0)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  clone = state.Clone()
+  err = clone.ApplyBlock(blk)
+  if err != nil {
+    return chain.SigBlock{}, err
+  }
+  state.Apply(clone)
+  return blk, nil
+}
+
+type evidenceRelay struct {
+  evs []chain.Evidence
+}
+
+func (r *evidenceRelay) RelayEvidence(ev chain.Evidence) {
+  r.evs = append(r.evs, ev)
+}
+
+func TestChainReorg(t *testing.T) {
+  defer os.RemoveAll(bootKeyStoreDir)
+  defer os.RemoveAll(bootBlockStoreDir)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  wg := new(sync.WaitGroup)
+  // Initialize the state on the bootstrap node by creating the genesis
+  bootPeerDisc := createPeerDiscovery(ctx, wg, true, false)
+  nodeCfg := node.NodeCfg{
+    NodeAddr: bootAddr, Bootstrap: true,
+    KeyStoreDir: bootKeyStoreDir, BlockStoreDir: bootBlockStoreDir,
+    Chain: chainName, AuthPass: authPass,
+    OwnerPass: ownerPass, Balance: ownerBalance,
+  }
+  stateSync := node.NewStateSync(ctx, nodeCfg, bootPeerDisc)
+  evRelay := &evidenceRelay{}
+  stateSync.SetEvidenceRelayer(evRelay)
+  state, err := stateSync.SyncState()
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Re-create the authority account and the initial owner account
+  gen, err := chain.ReadGenesis(bootBlockStoreDir)
+  if err != nil {
+    t.Fatal(err)
+  }
+  path := filepath.Join(bootKeyStoreDir, string(gen.Authority))
+  auth, err := chain.ReadAccount(path, []byte(authPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  ownerAcc, ownerBal := genesisAccount(gen)
+  path = filepath.Join(bootKeyStoreDir, string(ownerAcc))
+  acc, err := chain.ReadAccount(path, []byte(ownerPass))
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Create the block of the main chain and the two blocks of the competing
+  // branch from the same genesis
+  mainBlk, err := createForkBlock(auth, acc, chain.NewState(gen), 12)
+  if err != nil {
+    t.Fatal(err)
+  }
+  forkState := chain.NewState(gen)
+  forkBlk1, err := createForkBlock(auth, acc, forkState, 34)
+  if err != nil {
+    t.Fatal(err)
+  }
+  forkBlk2, err := createForkBlock(auth, acc, forkState, 5)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // Verify that the block extending the main chain is applied
+  reverted, applied, err := stateSync.ApplyForkBlock(mainBlk)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(reverted) != 0 || len(applied) != 1 {
+    t.Errorf("invalid main chain block application")
+  }
+  // Verify that the competing block with equal work is kept as the candidate
+  // block without the reorg
+  reverted, applied, err = stateSync.ApplyForkBlock(forkBlk1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(reverted) != 0 || len(applied) != 0 {
+    t.Errorf("reorg on competing branch with equal work")
+  }
+  if state.LastBlock().Hash() != mainBlk.Hash() {
+    t.Errorf("invalid last block: expected main chain block")
+  }
+  // Verify that the authority signing both competing blocks at the same block
+  // number is reported as the equivocation
+  ev := chain.NewEvidence(mainBlk, forkBlk1)
+  if len(evRelay.evs) != 1 || evRelay.evs[0].Hash() != ev.Hash() {
+    t.Errorf("invalid evidence: expected equivocation of authority")
+  }
+  // Verify that the known candidate block is rejected
+  _, _, err = stateSync.ApplyForkBlock(forkBlk1)
+  if err == nil {
+    t.Errorf("known candidate block applied")
+  }
+  // Verify that the competing branch with more work reorganizes the chain
+  reverted, applied, err = stateSync.ApplyForkBlock(forkBlk2)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(reverted) != 1 || reverted[0].Hash() != mainBlk.Hash() {
+    t.Errorf("invalid reverted blocks: expected main chain block")
+  }
+  if len(applied) != 2 || applied[1].Hash() != forkBlk2.Hash() {
+    t.Errorf("invalid applied blocks: expected competing branch")
+  }
+  // Verify that the state is rolled forward along the competing branch
+  if state.LastBlock().Hash() != forkBlk2.Hash() {
+    t.Errorf("invalid last block: expected competing branch tip")
+  }
+  expBalance := ownerBal - 34 - 5
+  gotBalance, _ := state.Balance(acc.Address())
+  if gotBalance != expBalance {
+    t.Errorf("invalid balance: expected %v, got %v", expBalance, gotBalance)
+  }
+  // Verify that the block store contains the blocks of the new main chain
+  blockStore := stateSync.BlockStore()
+  height, err := blockStore.Height()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if height != 2 {
+    t.Errorf("invalid block store height: expected %v, got %v", 2, height)
+  }
+  for _, exp := range []chain.SigBlock{forkBlk1, forkBlk2} {
+    got, err := blockStore.Block(exp.Number)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if got.Hash() != exp.Hash() {
+      t.Errorf("invalid block %v in block store", exp.Number)
+    }
+  }
+  _, found, err := blockStore.FindBlock(mainBlk.Hash().String())
+  if err != nil {
+    t.Fatal(err)
+  }
+  if found {
+    t.Errorf("reverted block found in block store")
+  }
 }